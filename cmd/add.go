@@ -9,15 +9,16 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
-	"github.com/indiekitai/mrr-cli/db"
 	"github.com/indiekitai/mrr-cli/models"
 )
 
 var (
-	addSource string
-	addType   string
-	addNote   string
-	addDate   string
+	addSource   string
+	addType     string
+	addNote     string
+	addDate     string
+	addCustomer string
+	addCurrency string
 )
 
 var addCmd = &cobra.Command{
@@ -29,26 +30,30 @@ Examples:
   mrr add 29.99
   mrr add 99.00 --source stripe --type recurring
   mrr add 49.99 --source gumroad --note "Lifetime license"
-  mrr add 100 --date 2024-01-15`,
+  mrr add 100 --date 2024-01-15
+  mrr add 29 --customer acme
+  mrr add 25 --currency EUR --source paddle`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAdd,
 }
 
 func init() {
-	addCmd.Flags().StringVarP(&addSource, "source", "s", "manual", "Revenue source (stripe, gumroad, paddle, manual)")
+	addCmd.Flags().StringVarP(&addSource, "source", "s", "manual", "Revenue source (stripe, gumroad, paddle, lemonsqueezy, manual)")
 	addCmd.Flags().StringVarP(&addType, "type", "t", "recurring", "Revenue type (recurring, one-time)")
 	addCmd.Flags().StringVarP(&addNote, "note", "n", "", "Note for this entry")
 	addCmd.Flags().StringVarP(&addDate, "date", "d", "", "Date (YYYY-MM-DD, defaults to today)")
+	addCmd.Flags().StringVarP(&addCustomer, "customer", "c", "", "Customer name or ID")
+	addCmd.Flags().StringVar(&addCurrency, "currency", "", "ISO currency code (defaults to reporting currency)")
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	// Parse amount
-	amountStr := strings.TrimPrefix(args[0], "$")
-	amountFloat, err := strconv.ParseFloat(amountStr, 64)
+	amountCents, err := parseAmount(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid amount: %s", args[0])
+		return err
 	}
-	amountCents := int64(amountFloat * 100)
 
 	// Validate source
 	if !models.IsValidSource(addSource) {
@@ -60,6 +65,12 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid type: %s (valid: %v)", addType, models.ValidTypes)
 	}
 
+	// Validate currency
+	currency, err := parseCurrency(store, addCurrency)
+	if err != nil {
+		return err
+	}
+
 	// Parse date
 	var date time.Time
 	if addDate != "" {
@@ -71,8 +82,14 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		date = time.Now()
 	}
 
+	// Resolve customer
+	customerID, err := resolveCustomer(store, addCustomer)
+	if err != nil {
+		return err
+	}
+
 	// Add to database
-	id, err := db.AddEntry(amountCents, addSource, addType, addNote, date)
+	id, err := store.AddEntry(amountCents, currency, addSource, addType, addNote, date, customerID)
 	if err != nil {
 		return err
 	}
@@ -83,10 +100,32 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s Added entry #%s: %s from %s (%s)\n",
 		green("✓"),
 		cyan(fmt.Sprintf("%d", id)),
-		models.FormatAmount(amountCents, "USD"),
+		models.FormatAmount(amountCents, currency),
 		addSource,
 		addType,
 	)
 
 	return nil
 }
+
+// parseAmount parses an amount like 29.99 or $29.99 into cents
+func parseAmount(s string) (int64, error) {
+	amountFloat, err := strconv.ParseFloat(strings.TrimPrefix(s, "$"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", s)
+	}
+	return models.AmountToCents(amountFloat)
+}
+
+// parsePositiveAmount is parseAmount for amounts that can't be negative,
+// like subscription prices and expenses
+func parsePositiveAmount(s string) (int64, error) {
+	cents, err := parseAmount(s)
+	if err != nil {
+		return 0, err
+	}
+	if cents < 0 {
+		return 0, fmt.Errorf("invalid amount: %s (must be positive)", s)
+	}
+	return cents, nil
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// maxAPIBody caps the size of an API request body
+const maxAPIBody = 1 << 20
+
+// apiServer serves the versioned REST API from a store
+type apiServer struct {
+	store db.Store
+}
+
+// NewAPIHandler returns the /api/v1 REST API backed by s. Requests need a
+// bearer token from 'mrr token create'; only read-write tokens may change
+// entries.
+//
+//	GET    /api/v1/entries          ?month= &source= &type= &customer= &origin=
+//	POST   /api/v1/entries
+//	GET    /api/v1/entries/{id}
+//	PATCH  /api/v1/entries/{id}
+//	DELETE /api/v1/entries/{id}
+//	GET    /api/v1/report           ?month= &multiplier=
+//	GET    /api/v1/forecast
+//	GET    /api/v1/goal
+func NewAPIHandler(s db.Store) http.Handler {
+	srv := &apiServer{store: s}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/entries", srv.handleEntries)
+	mux.HandleFunc("/api/v1/entries/", srv.handleEntry)
+	mux.HandleFunc("/api/v1/report", srv.handleReport)
+	mux.HandleFunc("/api/v1/forecast", srv.handleForecast)
+	mux.HandleFunc("/api/v1/goal", srv.handleGoal)
+	mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "not found: %s", r.URL.Path)
+	})
+
+	return srv.authenticate(mux)
+}
+
+// authenticate checks the request's bearer token and its scope before
+// passing it on. Browsers' CORS preflight requests pass without a token.
+func (srv *apiServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || strings.TrimSpace(secret) == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mrr"`)
+			writeAPIError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		token, err := srv.store.GetAPITokenByHash(hashToken(strings.TrimSpace(secret)))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mrr", error="invalid_token"`)
+			writeAPIError(w, http.StatusUnauthorized, "invalid or revoked token")
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !token.CanWrite() {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mrr", error="insufficient_scope"`)
+			writeAPIError(w, http.StatusForbidden, "token %s is read-only", token.Name)
+			return
+		}
+
+		srv.store.TouchAPIToken(token.ID, time.Now())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// entryRequest is the body of POST and PATCH /entries. Omitted fields
+// take their defaults when creating and are left alone when updating.
+type entryRequest struct {
+	Amount   *float64 `json:"amount"`
+	Currency *string  `json:"currency"`
+	Source   *string  `json:"source"`
+	Type     *string  `json:"type"`
+	Note     *string  `json:"note"`
+	Date     *string  `json:"date"` // YYYY-MM-DD
+	Customer *string  `json:"customer"`
+}
+
+func (srv *apiServer) handleEntries(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		srv.listEntries(w, r)
+	case http.MethodPost:
+		srv.createEntry(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (srv *apiServer) handleEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1/entries/"), 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "invalid entry ID: %s", strings.TrimPrefix(r.URL.Path, "/api/v1/entries/"))
+		return
+	}
+
+	entry, err := srv.store.GetEntry(id)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeAPIJSON(w, http.StatusOK, newListEntry(*entry))
+	case http.MethodPatch:
+		srv.updateEntry(w, r, id)
+	case http.MethodDelete:
+		if err := srv.store.DeleteEntry(id); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+func (srv *apiServer) listEntries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	customerID, err := resolveCustomer(srv.store, query.Get("customer"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	entries, err := srv.store.ListEntries(query.Get("month"), query.Get("source"), query.Get("type"), customerID)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	entries = filterOrigin(entries, query.Get("origin"))
+
+	total, err := sumEntries(srv.store, entries)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	output := newListOutput(entries, total, srv.store.ReportingCurrency())
+	if output.Entries == nil {
+		output.Entries = []listEntry{}
+	}
+	writeAPIJSON(w, http.StatusOK, output)
+}
+
+func (srv *apiServer) createEntry(w http.ResponseWriter, r *http.Request) {
+	var req entryRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+	if req.Amount == nil {
+		writeAPIError(w, http.StatusBadRequest, "amount is required")
+		return
+	}
+	amount, err := models.AmountToCents(*req.Amount)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	source, entryType, note := "manual", "recurring", ""
+	if req.Source != nil {
+		source = *req.Source
+	}
+	if req.Type != nil {
+		entryType = *req.Type
+	}
+	if req.Note != nil {
+		note = *req.Note
+	}
+	if !models.IsValidSource(source) {
+		writeAPIError(w, http.StatusBadRequest, "invalid source: %s (valid: %v)", source, models.ValidSources)
+		return
+	}
+	if !models.IsValidType(entryType) {
+		writeAPIError(w, http.StatusBadRequest, "invalid type: %s (valid: %v)", entryType, models.ValidTypes)
+		return
+	}
+
+	currency := srv.store.ReportingCurrency()
+	if req.Currency != nil {
+		currency = strings.ToUpper(strings.TrimSpace(*req.Currency))
+		if !models.IsValidCurrency(currency) {
+			writeAPIError(w, http.StatusBadRequest, "invalid currency: %s (use an ISO code like USD, EUR)", *req.Currency)
+			return
+		}
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		if date, err = time.Parse("2006-01-02", *req.Date); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid date format: %s (use YYYY-MM-DD)", *req.Date)
+			return
+		}
+	}
+
+	var customerID int64
+	if req.Customer != nil {
+		if customerID, err = resolveCustomer(srv.store, *req.Customer); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+	}
+
+	id, err := srv.store.AddEntry(amount, currency, source, entryType, note, date, customerID)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	entry, err := srv.store.GetEntry(id)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/entries/%d", id))
+	writeAPIJSON(w, http.StatusCreated, newListEntry(*entry))
+}
+
+func (srv *apiServer) updateEntry(w http.ResponseWriter, r *http.Request, id int64) {
+	var req entryRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+	if req.Type != nil || req.Date != nil {
+		writeAPIError(w, http.StatusBadRequest, "type and date can't be changed; delete the entry and add it again")
+		return
+	}
+
+	var amount, customerID *int64
+	var currency *string
+	if req.Amount != nil {
+		cents, err := models.AmountToCents(*req.Amount)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		amount = &cents
+	}
+	if req.Currency != nil {
+		c := strings.ToUpper(strings.TrimSpace(*req.Currency))
+		if !models.IsValidCurrency(c) {
+			writeAPIError(w, http.StatusBadRequest, "invalid currency: %s (use an ISO code like USD, EUR)", *req.Currency)
+			return
+		}
+		currency = &c
+	}
+	if req.Source != nil && !models.IsValidSource(*req.Source) {
+		writeAPIError(w, http.StatusBadRequest, "invalid source: %s (valid: %v)", *req.Source, models.ValidSources)
+		return
+	}
+	if req.Customer != nil {
+		cid, err := resolveCustomer(srv.store, *req.Customer)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		customerID = &cid
+	}
+
+	if amount == nil && currency == nil && req.Source == nil && req.Note == nil && customerID == nil {
+		writeAPIError(w, http.StatusBadRequest, "no fields to update (amount, currency, source, note or customer)")
+		return
+	}
+
+	if err := srv.store.UpdateEntry(id, amount, currency, req.Source, req.Note, customerID); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	entry, err := srv.store.GetEntry(id)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, newListEntry(*entry))
+}
+
+func (srv *apiServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	month := r.URL.Query().Get("month")
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	} else if _, err := time.Parse("2006-01", month); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid month: %s (use YYYY-MM)", month)
+		return
+	}
+
+	multiplier := 3.0
+	if m := r.URL.Query().Get("multiplier"); m != "" {
+		var err error
+		if multiplier, err = strconv.ParseFloat(m, 64); err != nil || multiplier <= 0 {
+			writeAPIError(w, http.StatusBadRequest, "invalid multiplier: %s", m)
+			return
+		}
+	}
+
+	data, _, err := buildReport(srv.store, month, multiplier)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, data)
+}
+
+func (srv *apiServer) handleForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	data, err := buildForecast(srv.store)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, data)
+}
+
+func (srv *apiServer) handleGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	goal, err := GetGoal()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if goal == nil {
+		writeAPIError(w, http.StatusNotFound, "no goal set")
+		return
+	}
+
+	data, err := buildGoalStatus(srv.store, goal)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, data)
+}
+
+// decodeAPIRequest decodes a JSON request body into v, answering 400 and
+// returning false if it is invalid
+func decodeAPIRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeAPIJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+func methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+func TestAPIRejectsInvalidAmounts(t *testing.T) {
+	s := db.NewMemoryStore()
+	if _, err := s.AddAPIToken("test", models.ScopeReadWrite, hashToken("mrr_test"), "mrr_test"); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewAPIHandler(s)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer mrr_test")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, amount := range []string{"0", "0.001", "1e300", "-1e300", "1000000000.01"} {
+		rec := request(http.MethodPost, "/api/v1/entries", `{"amount": `+amount+`}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("POST amount %s: HTTP %d, want 400: %s", amount, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := request(http.MethodPost, "/api/v1/entries", `{"amount": 29.99}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST amount 29.99: HTTP %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := request(http.MethodPatch, "/api/v1/entries/1", `{"amount": 1e300}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("PATCH amount 1e300: HTTP %d, want 400: %s", rec.Code, rec.Body.String())
+	}
+
+	entries, err := s.ListEntries("", "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Amount != 2999 {
+		t.Errorf("entries = %+v, want one of 2999", entries)
+	}
+}
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+// backupFormat is the version of the backup archive layout
+const backupFormat = 1
+
+var (
+	backupOutput string
+	restoreMerge bool
+	restoreForce bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the database and config",
+	Long: `Back up the current workspace to a .tar.gz archive holding a consistent
+snapshot of the database (taken with SQLite's online backup API, so it is safe
+while mrr is running elsewhere) and the workspace config with the goal,
+reporting currency and import profiles.
+
+Examples:
+  mrr backup
+  mrr backup --output mrr-2026-10.tar.gz
+  mrr --workspace saas-b backup`,
+	RunE: runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore a backup",
+	Long: `Restore a backup made with 'mrr backup' into the current workspace.
+
+By default the workspace's database and config are replaced by the backup's.
+With --merge, customers, entries, subscriptions, FX rates, expenses and
+acquisition spend missing from the workspace are added and the current
+config is kept, filling in only what it lacks. Backups from older versions
+are migrated; backups from newer versions are refused.
+
+Examples:
+  mrr restore mrr-2026-10.tar.gz
+  mrr restore mrr-2026-10.tar.gz --merge
+  mrr restore mrr-2026-10.tar.gz --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Archive path (default mrr-<workspace>-<date>.tar.gz)")
+
+	restoreCmd.Flags().BoolVar(&restoreMerge, "merge", false, "Merge into the current workspace instead of replacing it")
+	restoreCmd.Flags().BoolVarP(&restoreForce, "force", "f", false, "Skip confirmation")
+}
+
+// backupManifest describes a backup archive
+type backupManifest struct {
+	Format        int    `json:"format"`
+	SchemaVersion int    `json:"schema_version"`
+	Workspace     string `json:"workspace"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	sqlite, err := sqliteStore(storeFrom(cmd), "backups")
+	if err != nil {
+		return err
+	}
+
+	version, err := sqlite.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	output := backupOutput
+	if output == "" {
+		output = fmt.Sprintf("mrr-%s-%s.tar.gz", currentWorkspace(), time.Now().Format("2006-01-02"))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "mrr-backup-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dbPath := filepath.Join(tmpDir, "data.db")
+	if err := sqlite.Backup(dbPath); err != nil {
+		return err
+	}
+
+	manifest, err := json.MarshalIndent(backupManifest{
+		Format:        backupFormat,
+		SchemaVersion: version,
+		Workspace:     currentWorkspace(),
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := writeBackup(output, manifest, dbPath, config); err != nil {
+		os.Remove(output)
+		return err
+	}
+
+	info, err := os.Stat(output)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("%s Backed up workspace %s to %s (%s, schema version %d)\n",
+		green("✓"), cyan(currentWorkspace()), output, formatSize(info.Size()), version)
+
+	return nil
+}
+
+// writeBackup writes the archive: manifest.json, data.db and config.json
+// when there is a config. The config holds API keys and webhook secrets, so
+// the archive is readable only by its owner like config.json itself.
+func writeBackup(path string, manifest []byte, dbPath string, config []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	defer file.Close()
+	// Tighten an existing file too; OpenFile only applies the mode on create
+	if err := file.Chmod(0600); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+	archive := tar.NewWriter(gz)
+
+	now := time.Now()
+	add := func(name string, size int64, r io.Reader) error {
+		header := &tar.Header{Name: name, Mode: 0600, Size: size, ModTime: now}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		if _, err := io.Copy(archive, r); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		return nil
+	}
+
+	if err := add("manifest.json", int64(len(manifest)), bytes.NewReader(manifest)); err != nil {
+		return err
+	}
+
+	dbFile, err := os.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database snapshot: %w", err)
+	}
+	defer dbFile.Close()
+	info, err := dbFile.Stat()
+	if err != nil {
+		return err
+	}
+	if err := add("data.db", info.Size(), dbFile); err != nil {
+		return err
+	}
+
+	if config != nil {
+		if err := add("config.json", int64(len(config)), bytes.NewReader(config)); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return file.Close()
+}
+
+// readBackup extracts an archive's database into dir and returns its
+// manifest and config, if any
+func readBackup(path, dir string) (*backupManifest, *Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	archive := tar.NewReader(gz)
+
+	var manifest *backupManifest
+	var config *Config
+	hasDB := false
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		switch header.Name {
+		case "manifest.json":
+			manifest = &backupManifest{}
+			if err := json.NewDecoder(archive).Decode(manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid backup manifest: %w", err)
+			}
+		case "config.json":
+			config = &Config{}
+			if err := json.NewDecoder(archive).Decode(config); err != nil {
+				return nil, nil, fmt.Errorf("invalid backup config: %w", err)
+			}
+		case "data.db":
+			out, err := os.OpenFile(filepath.Join(dir, "data.db"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to extract database: %w", err)
+			}
+			_, err = io.Copy(out, archive)
+			out.Close()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to extract database: %w", err)
+			}
+			hasDB = true
+		}
+	}
+
+	if manifest == nil || !hasDB {
+		return nil, nil, fmt.Errorf("not an mrr backup: missing manifest.json or data.db")
+	}
+	if manifest.Format > backupFormat {
+		return nil, nil, fmt.Errorf("backup format %d is newer than this version of mrr supports (%d); please upgrade mrr", manifest.Format, backupFormat)
+	}
+
+	return manifest, config, nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	if !restoreMerge && storeInjected(cmd) {
+		return fmt.Errorf("replacing the database needs a SQLite database; use --merge")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "mrr-restore-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifest, config, err := readBackup(args[0], tmpDir)
+	if err != nil {
+		return err
+	}
+
+	backupPath := filepath.Join(tmpDir, "data.db")
+	backup, err := db.OpenSQLiteStore(backupPath)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+
+	version, err := backup.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+	if version != manifest.SchemaVersion {
+		return fmt.Errorf("invalid backup: database schema version %d does not match manifest (%d)", version, manifest.SchemaVersion)
+	}
+	if _, err := backup.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate backup: %w", err)
+	}
+
+	entries, err := backup.ListEntries("", "", "", 0)
+	if err != nil {
+		return err
+	}
+	subs, err := backup.ListSubscriptions(false)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("Backup of workspace %s from %s: %d entries, %d subscriptions (schema version %d)\n",
+		cyan(manifest.Workspace), manifest.CreatedAt, len(entries), len(subs), version)
+
+	if restoreMerge {
+		result, err := db.Merge(store, backup)
+		if err != nil {
+			return err
+		}
+		if config != nil {
+			if err := mergeConfig(config); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("%s Merged into workspace %s: %d entries, %d subscriptions, %d cancellations, %d customers, %d FX rates, %d expenses, %d months of acquisition spend added\n",
+			green("✓"), cyan(currentWorkspace()), result.Entries, result.Subscriptions, result.Cancellations, result.Customers, result.FXRates, result.Expenses, result.Spend)
+		return nil
+	}
+
+	if !restoreForce {
+		fmt.Printf("%s Replace workspace %s with this backup? Its current data will be lost. [y/N] ", yellow("⚠"), currentWorkspace())
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	path, err := databasePath()
+	if err != nil {
+		return err
+	}
+
+	backup.Close()
+	closeStore(cmd)
+
+	if err := replaceFile(backupPath, path); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	if config == nil {
+		config = &Config{}
+	}
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Restored workspace %s\n", green("✓"), cyan(currentWorkspace()))
+
+	return nil
+}
+
+// mergeConfig fills in settings the current config lacks from a backup's
+func mergeConfig(backup *Config) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.Goal == nil {
+		config.Goal = backup.Goal
+	}
+	if config.Currency == "" {
+		config.Currency = backup.Currency
+	} else if backup.Currency != "" && backup.Currency != config.Currency {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s Keeping reporting currency %s (backup uses %s)\n", yellow("⚠"), config.Currency, backup.Currency)
+	}
+	for name, profile := range backup.ImportProfiles {
+		if _, ok := config.ImportProfiles[name]; ok {
+			continue
+		}
+		if config.ImportProfiles == nil {
+			config.ImportProfiles = make(map[string]*ImportProfile)
+		}
+		config.ImportProfiles[name] = profile
+	}
+
+	return saveConfig(config)
+}
+
+// replaceFile copies src over dst via a temporary file in dst's directory,
+// so dst is never left half written
+func replaceFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// A journal left by the replaced database must not be applied to the
+	// restored one
+	os.Remove(dst + "-journal")
+	os.Remove(dst + "-wal")
+	os.Remove(dst + "-shm")
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+// formatSize formats a byte count for display
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupIsPrivate(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "data.db")
+	if err := os.WriteFile(dbPath, []byte("db"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Overwrites an existing world-readable file too
+	path := filepath.Join(dir, "backup.tar.gz")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := []byte(`{"format": 1, "schema_version": 1, "workspace": "default"}`)
+	if err := writeBackup(path, manifest, dbPath, []byte(`{"stripe_api_key": "sk_test"}`)); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("backup mode = %o, want 600", mode)
+	}
+
+	extract := t.TempDir()
+	if _, _, err := readBackup(path, extract); err != nil {
+		t.Fatal(err)
+	}
+	info, err = os.Stat(filepath.Join(extract, "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("extracted database mode = %o, want 600", mode)
+	}
+}
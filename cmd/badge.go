@@ -3,13 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-
-	"github.com/indiekitai/mrr-cli/db"
-	"github.com/indiekitai/mrr-cli/models"
 )
 
 var badgeOutput string
@@ -20,6 +16,10 @@ var badgeCmd = &cobra.Command{
 	Long: `Generate an SVG badge displaying current MRR.
 Similar to shields.io badges, suitable for README files.
 
+The badge follows the privacy settings (see 'mrr privacy'): it shows a
+rounded or bucketed MRR, or only the growth, if that is all that may be
+published.
+
 Examples:
   mrr badge                      # Output to stdout
   mrr badge --output mrr.svg     # Save to file`,
@@ -31,15 +31,18 @@ func init() {
 }
 
 func runBadge(cmd *cobra.Command, args []string) error {
-	currentMonth := time.Now().Format("2006-01")
+	store := storeFrom(cmd)
 
-	report, err := db.GetMonthlyReport(currentMonth)
+	privacy, err := loadPrivacy()
 	if err != nil {
 		return err
 	}
 
-	mrrStr := models.FormatAmount(report.RecurringRevenue, "USD")
-	svg := generateBadgeSVG("MRR", mrrStr)
+	data, err := publicDashboardData(store, privacy, "")
+	if err != nil {
+		return err
+	}
+	svg := dashboardBadges(data)["mrr"]
 
 	if badgeOutput != "" {
 		err := os.WriteFile(badgeOutput, []byte(svg), 0644)
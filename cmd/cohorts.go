@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// cohortPeriods maps each --by value to its length in months
+var cohortPeriods = map[string]int{"month": 1, "quarter": 3}
+
+var (
+	cohortsBy     string
+	cohortsMetric string
+	cohortsFrom   string
+	cohortsTo     string
+	cohortsJSON   bool
+	cohortsCSV    bool
+)
+
+var cohortsCmd = &cobra.Command{
+	Use:   "cohorts",
+	Short: "Show cohort retention",
+	Long: `Show how each signup cohort retained over time.
+
+Customers are grouped by the month (or quarter) of their first subscription.
+Each row is a cohort and each column the periods since signup:
+
+  customers   Share of the cohort's customers still paying
+  revenue     The cohort's MRR as a share of its MRR at the end of the
+              signup period; above 100% when upgrades outweigh churn
+
+Examples:
+  mrr cohorts                                 # Monthly customer retention
+  mrr cohorts --metric revenue
+  mrr cohorts --by quarter --from 2025-01
+  mrr cohorts --csv > cohorts.csv             # For spreadsheets
+  mrr cohorts --json`,
+	Args: cobra.NoArgs,
+	RunE: runCohorts,
+}
+
+func init() {
+	cohortsCmd.Flags().StringVar(&cohortsBy, "by", "month", "Cohort period (month, quarter)")
+	cohortsCmd.Flags().StringVar(&cohortsMetric, "metric", "customers", "Retention metric (customers, revenue)")
+	cohortsCmd.Flags().StringVar(&cohortsFrom, "from", "", "First cohort (YYYY-MM, defaults to the first signup)")
+	cohortsCmd.Flags().StringVar(&cohortsTo, "to", "", "Last cohort (YYYY-MM, defaults to current)")
+	cohortsCmd.Flags().BoolVarP(&cohortsJSON, "json", "j", false, "Output as JSON")
+	cohortsCmd.Flags().BoolVar(&cohortsCSV, "csv", false, "Output as CSV")
+}
+
+type cohortData struct {
+	Cohort    string     `json:"cohort"` // First month of the signup period
+	Size      int        `json:"size"`
+	Customers []int      `json:"customers"` // Paying at the end of each period since signup
+	MRR       []float64  `json:"mrr"`
+	Retention []*float64 `json:"retention"` // Percent by the chosen metric, null without a base
+}
+
+type cohortsOutput struct {
+	By       string       `json:"by"`
+	Metric   string       `json:"metric"`
+	Currency string       `json:"currency"`
+	Cohorts  []cohortData `json:"cohorts"`
+}
+
+func newCohortData(c db.Cohort, metric string) cohortData {
+	data := cohortData{
+		Cohort:    c.Period,
+		Size:      c.Size,
+		Customers: c.Customers,
+		MRR:       []float64{},
+		Retention: []*float64{},
+	}
+	for i := range c.MRR {
+		data.MRR = append(data.MRR, float64(c.MRR[i])/100.0)
+
+		var retention *float64
+		if metric == "revenue" && len(c.MRR) > 0 && c.MRR[0] > 0 {
+			pct := float64(c.MRR[i]) / float64(c.MRR[0]) * 100
+			retention = &pct
+		} else if metric == "customers" && c.Size > 0 {
+			pct := float64(c.Customers[i]) / float64(c.Size) * 100
+			retention = &pct
+		}
+		data.Retention = append(data.Retention, retention)
+	}
+	return data
+}
+
+// alignPeriod returns the first month of the period of periodMonths holding t
+func alignPeriod(t time.Time, periodMonths int) time.Time {
+	month := (int(t.Month())-1)/periodMonths*periodMonths + 1
+	return time.Date(t.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func runCohorts(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	periodMonths, ok := cohortPeriods[cohortsBy]
+	if !ok {
+		return fmt.Errorf("invalid --by: %s (valid: month, quarter)", cohortsBy)
+	}
+	if cohortsMetric != "customers" && cohortsMetric != "revenue" {
+		return fmt.Errorf("invalid --metric: %s (valid: customers, revenue)", cohortsMetric)
+	}
+	if cohortsJSON && cohortsCSV {
+		return fmt.Errorf("--json and --csv can't be used together")
+	}
+
+	now := time.Now()
+	to := alignPeriod(now, periodMonths)
+	if cohortsTo != "" {
+		t, err := time.Parse("2006-01", cohortsTo)
+		if err != nil {
+			return fmt.Errorf("invalid month format, use YYYY-MM: %s", cohortsTo)
+		}
+		to = alignPeriod(t, periodMonths)
+	}
+
+	var from time.Time
+	if cohortsFrom != "" {
+		t, err := time.Parse("2006-01", cohortsFrom)
+		if err != nil {
+			return fmt.Errorf("invalid month format, use YYYY-MM: %s", cohortsFrom)
+		}
+		from = alignPeriod(t, periodMonths)
+	} else {
+		subs, err := store.ListSubscriptions(false)
+		if err != nil {
+			return err
+		}
+		first := now
+		for _, sub := range subs {
+			if sub.StartDate.Before(first) {
+				first = sub.StartDate
+			}
+		}
+		from = alignPeriod(first, periodMonths)
+	}
+	if to.After(alignPeriod(now, periodMonths)) {
+		to = alignPeriod(now, periodMonths)
+	}
+	if to.Before(from) {
+		return fmt.Errorf("last cohort %s is before first cohort %s", to.Format("2006-01"), from.Format("2006-01"))
+	}
+
+	cohorts, err := db.GetCohorts(store, from.Format("2006-01"), to.Format("2006-01"), periodMonths, now)
+	if err != nil {
+		return err
+	}
+
+	output := cohortsOutput{
+		By:       cohortsBy,
+		Metric:   cohortsMetric,
+		Currency: store.ReportingCurrency(),
+		Cohorts:  []cohortData{},
+	}
+	for _, c := range cohorts {
+		output.Cohorts = append(output.Cohorts, newCohortData(c, cohortsMetric))
+	}
+
+	if cohortsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	// Columns: one per period since the oldest cohort's signup
+	periods := 0
+	for _, c := range output.Cohorts {
+		if len(c.Retention) > periods {
+			periods = len(c.Retention)
+		}
+	}
+
+	if cohortsCSV {
+		return writeCohortsCSV(output, periods)
+	}
+
+	hasCustomers := false
+	for _, c := range output.Cohorts {
+		if c.Size > 0 {
+			hasCustomers = true
+		}
+	}
+	if !hasCustomers {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No subscription customers signed up in these periods. Cohorts are built from subscriptions ('mrr sub add').\n", yellow("⚠"))
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("Cohort Retention by %s (%s)", cohortsBy, cohortsMetric)))
+	fmt.Println()
+
+	header := []string{"Cohort", "Size"}
+	if cohortsMetric == "revenue" {
+		header = append(header, "Start MRR")
+	}
+	for p := 0; p < periods; p++ {
+		header = append(header, strconv.Itoa(p))
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, len(header))
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, c := range output.Cohorts {
+		row := []string{c.Cohort, strconv.Itoa(c.Size)}
+		colors := []tablewriter.Colors{{tablewriter.FgBlueColor}, {}}
+		if cohortsMetric == "revenue" {
+			startMRR := 0.0
+			if len(c.MRR) > 0 {
+				startMRR = c.MRR[0]
+			}
+			row = append(row, models.FormatAmount(int64(math.Round(startMRR*100)), output.Currency))
+			colors = append(colors, tablewriter.Colors{tablewriter.FgGreenColor})
+		}
+
+		for p := 0; p < periods; p++ {
+			if p >= len(c.Retention) {
+				row = append(row, "")
+				colors = append(colors, tablewriter.Colors{})
+				continue
+			}
+			row = append(row, formatRate(c.Retention[p]))
+			colors = append(colors, retentionColor(c.Retention[p]))
+		}
+
+		table.Rich(row, colors)
+	}
+
+	table.Render()
+	fmt.Println()
+
+	return nil
+}
+
+// retentionColor colors a retention percentage from green to red
+func retentionColor(pct *float64) tablewriter.Colors {
+	switch {
+	case pct == nil:
+		return tablewriter.Colors{}
+	case *pct >= 80:
+		return tablewriter.Colors{tablewriter.FgGreenColor}
+	case *pct >= 50:
+		return tablewriter.Colors{tablewriter.FgYellowColor}
+	default:
+		return tablewriter.Colors{tablewriter.FgRedColor}
+	}
+}
+
+// writeCohortsCSV writes one row per cohort with its retention per period
+func writeCohortsCSV(output cohortsOutput, periods int) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	header := []string{"cohort", "size", "start_mrr"}
+	for p := 0; p < periods; p++ {
+		header = append(header, strconv.Itoa(p))
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, c := range output.Cohorts {
+		startMRR := 0.0
+		if len(c.MRR) > 0 {
+			startMRR = c.MRR[0]
+		}
+		record := []string{c.Cohort, strconv.Itoa(c.Size), fmt.Sprintf("%.2f", startMRR)}
+		for p := 0; p < periods; p++ {
+			if p < len(c.Retention) && c.Retention[p] != nil {
+				record = append(record, fmt.Sprintf("%.1f", *c.Retention[p]))
+			} else {
+				record = append(record, "")
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	customerEmail string
+	customerNote  string
+	customerJSON  bool
+)
+
+var customerCmd = &cobra.Command{
+	Use:   "customer",
+	Short: "Manage customers",
+	Long: `Manage customers and view per-customer revenue history.
+
+Entries are linked to customers with --customer on add, edit and import.
+Subscriptions are matched to customers by name.
+
+Examples:
+  mrr customer add acme --email billing@acme.com
+  mrr customer list
+  mrr customer show acme`,
+}
+
+var customerAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a customer",
+	Long: `Add a customer. Names must be unique.
+
+Examples:
+  mrr customer add acme
+  mrr customer add acme --email billing@acme.com --note "Referred by Bob"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCustomerAdd,
+}
+
+var customerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List customers",
+	Long: `List customers with their current MRR and lifetime revenue.
+
+Examples:
+  mrr customer list
+  mrr customer list --json`,
+	RunE: runCustomerList,
+}
+
+var customerShowCmd = &cobra.Command{
+	Use:   "show <name|id>",
+	Short: "Show a customer's revenue history",
+	Long: `Show lifetime revenue, current MRR, first/last payment and tenure for a customer.
+
+Examples:
+  mrr customer show acme
+  mrr customer show 3 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCustomerShow,
+}
+
+func init() {
+	customerAddCmd.Flags().StringVarP(&customerEmail, "email", "e", "", "Customer email")
+	customerAddCmd.Flags().StringVarP(&customerNote, "note", "n", "", "Note for this customer")
+
+	customerListCmd.Flags().BoolVarP(&customerJSON, "json", "j", false, "Output as JSON")
+	customerShowCmd.Flags().BoolVarP(&customerJSON, "json", "j", false, "Output as JSON")
+
+	customerCmd.AddCommand(customerAddCmd)
+	customerCmd.AddCommand(customerListCmd)
+	customerCmd.AddCommand(customerShowCmd)
+}
+
+type customerData struct {
+	ID              int64   `json:"id"`
+	Name            string  `json:"name"`
+	Currency        string  `json:"currency"`
+	Email           string  `json:"email,omitempty"`
+	Note            string  `json:"note,omitempty"`
+	LifetimeRevenue float64 `json:"lifetime_revenue"`
+	CurrentMRR      float64 `json:"current_mrr"`
+	FirstPayment    string  `json:"first_payment,omitempty"`
+	LastPayment     string  `json:"last_payment,omitempty"`
+	PaymentCount    int     `json:"payment_count"`
+	TenureMonths    int     `json:"tenure_months"`
+	Active          bool    `json:"active"`
+}
+
+func newCustomerData(stats *db.CustomerStats, now time.Time) customerData {
+	data := customerData{
+		ID:              stats.Customer.ID,
+		Name:            stats.Customer.Name,
+		Currency:        stats.Currency,
+		Email:           stats.Customer.Email,
+		Note:            stats.Customer.Note,
+		LifetimeRevenue: float64(stats.LifetimeRevenue) / 100.0,
+		CurrentMRR:      float64(stats.CurrentMRR) / 100.0,
+		PaymentCount:    stats.PaymentCount,
+		TenureMonths:    stats.TenureMonths(now),
+		Active:          stats.Active,
+	}
+	if stats.FirstPayment != nil {
+		data.FirstPayment = stats.FirstPayment.Format("2006-01-02")
+		data.LastPayment = stats.LastPayment.Format("2006-01-02")
+	}
+	return data
+}
+
+// resolveCustomer looks up a customer in s by name or numeric ID and returns
+// its ID, or 0 if ref is empty
+func resolveCustomer(s db.Store, ref string) (int64, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, nil
+	}
+
+	customer, err := s.GetCustomerByName(ref)
+	if err == nil {
+		return customer.ID, nil
+	}
+
+	if id, convErr := strconv.ParseInt(ref, 10, 64); convErr == nil {
+		if customer, err := s.GetCustomer(id); err == nil {
+			return customer.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("customer not found: %s (add it with 'mrr customer add')", ref)
+}
+
+func runCustomerAdd(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("customer name is required")
+	}
+
+	id, err := store.AddCustomer(name, customerEmail, customerNote)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s Added customer #%s: %s\n", green("✓"), cyan(fmt.Sprintf("%d", id)), name)
+
+	return nil
+}
+
+func runCustomerList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	customers, err := store.ListCustomers()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data := []customerData{}
+	for _, c := range customers {
+		stats, err := db.GetCustomerStats(store, c.ID, now)
+		if err != nil {
+			return err
+		}
+		data = append(data, newCustomerData(stats, now))
+	}
+
+	if customerJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	if len(data) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No customers found.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Email", "MRR", "Lifetime", "Since"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, c := range data {
+		mrrColor := tablewriter.FgGreenColor
+		if !c.Active {
+			mrrColor = tablewriter.FgYellowColor
+		}
+
+		table.Rich([]string{
+			fmt.Sprintf("%d", c.ID),
+			c.Name,
+			c.Email,
+			models.FormatAmount(int64(c.CurrentMRR*100), c.Currency),
+			models.FormatAmount(int64(c.LifetimeRevenue*100), c.Currency),
+			c.FirstPayment,
+		}, []tablewriter.Colors{
+			{},
+			{tablewriter.FgMagentaColor},
+			{},
+			{mrrColor},
+			{tablewriter.FgGreenColor},
+			{},
+		})
+	}
+
+	table.Render()
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\n%s customers\n", cyan(fmt.Sprintf("%d", len(data))))
+
+	return nil
+}
+
+func runCustomerShow(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	id, err := resolveCustomer(store, args[0])
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stats, err := db.GetCustomerStats(store, id, now)
+	if err != nil {
+		return err
+	}
+
+	data := newCustomerData(stats, now)
+
+	if customerJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("Customer: %s", data.Name)))
+	fmt.Println("  " + strings.Repeat("─", 35))
+	fmt.Println()
+
+	if data.Email != "" {
+		fmt.Printf("  %s         %s\n", bold("Email:"), data.Email)
+	}
+	if data.Note != "" {
+		fmt.Printf("  %s          %s\n", bold("Note:"), data.Note)
+	}
+
+	if data.PaymentCount == 0 {
+		fmt.Printf("  %s No payments recorded for this customer.\n\n", yellow("⚠"))
+		return nil
+	}
+
+	fmt.Printf("  %s   %s\n", bold("Current MRR:"), green(models.FormatAmount(stats.CurrentMRR, stats.Currency)))
+	fmt.Printf("  %s      %s (%d payments)\n", bold("Lifetime:"), green(models.FormatAmount(stats.LifetimeRevenue, stats.Currency)), data.PaymentCount)
+	fmt.Printf("  %s %s\n", bold("First payment:"), data.FirstPayment)
+	fmt.Printf("  %s  %s\n", bold("Last payment:"), data.LastPayment)
+
+	tenure := fmt.Sprintf("%d months", data.TenureMonths)
+	if !data.Active {
+		tenure += " " + yellow("(churned)")
+	}
+	fmt.Printf("  %s        %s\n", bold("Tenure:"), tenure)
+	fmt.Println()
+
+	return nil
+}
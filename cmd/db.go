@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+var (
+	migrateStatus bool
+	migrateJSON   bool
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database maintenance",
+	Long: `Database maintenance commands.
+
+Pending schema migrations are applied automatically whenever mrr opens the
+database; 'mrr db migrate' lets you inspect and apply them explicitly.
+
+Examples:
+  mrr db migrate --status
+  mrr db migrate`,
+	// Open without migrating so --status can report pending migrations
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return openDatabase(cmd, false)
+	},
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long: `Apply pending schema migrations in a single transaction.
+
+Refuses to run against a database created by a newer version of mrr.
+
+Examples:
+  mrr db migrate
+  mrr db migrate --status
+  mrr db migrate --status --json`,
+	RunE: runDBMigrate,
+}
+
+func init() {
+	dbMigrateCmd.Flags().BoolVar(&migrateStatus, "status", false, "Show migration status without applying")
+	dbMigrateCmd.Flags().BoolVarP(&migrateJSON, "json", "j", false, "Output status as JSON")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+}
+
+type migrationEntry struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	AppliedAt   string `json:"applied_at,omitempty"`
+}
+
+type migrationOutput struct {
+	CurrentVersion int              `json:"current_version"`
+	LatestVersion  int              `json:"latest_version"`
+	Migrations     []migrationEntry `json:"migrations"`
+}
+
+// sqliteStore returns the store as a SQLite database, for features only a
+// database file has
+func sqliteStore(s db.Store, feature string) (*db.SQLiteStore, error) {
+	sqlite, ok := s.(*db.SQLiteStore)
+	if !ok {
+		return nil, fmt.Errorf("%s only apply to SQLite databases", feature)
+	}
+	return sqlite, nil
+}
+
+func runDBMigrate(cmd *cobra.Command, args []string) error {
+	sqlite, err := sqliteStore(storeFrom(cmd), "schema migrations")
+	if err != nil {
+		return err
+	}
+
+	if migrateStatus {
+		return printMigrationStatus(sqlite)
+	}
+
+	applied, err := sqlite.Migrate()
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+
+	if len(applied) == 0 {
+		fmt.Printf("%s Database is up to date (schema version %d)\n", green("✓"), db.LatestSchemaVersion())
+		return nil
+	}
+
+	for _, m := range applied {
+		fmt.Printf("%s Applied migration %d: %s\n", green("✓"), m.Version, m.Description)
+	}
+	fmt.Printf("\nSchema version is now %d\n", db.LatestSchemaVersion())
+
+	return nil
+}
+
+func printMigrationStatus(sqlite *db.SQLiteStore) error {
+	current, err := sqlite.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	status, err := sqlite.GetMigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	output := migrationOutput{
+		CurrentVersion: current,
+		LatestVersion:  db.LatestSchemaVersion(),
+		Migrations:     []migrationEntry{},
+	}
+	for _, s := range status {
+		entry := migrationEntry{Version: s.Version, Description: s.Description}
+		if s.AppliedAt != nil {
+			entry.AppliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		output.Migrations = append(output.Migrations, entry)
+	}
+
+	if migrateJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Version", "Description", "Applied"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	pending := 0
+	for _, m := range output.Migrations {
+		applied := m.AppliedAt
+		appliedColor := tablewriter.FgGreenColor
+		if applied == "" {
+			applied = "pending"
+			appliedColor = tablewriter.FgYellowColor
+			pending++
+		}
+
+		table.Rich([]string{
+			fmt.Sprintf("%d", m.Version),
+			m.Description,
+			applied,
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{appliedColor},
+		})
+	}
+
+	table.Render()
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	fmt.Printf("\nSchema version: %s (latest %d)", cyan(fmt.Sprintf("%d", current)), output.LatestVersion)
+	switch {
+	case current > output.LatestVersion:
+		fmt.Printf(" %s\n", red("— database is newer than this binary, upgrade mrr"))
+	case pending > 0:
+		fmt.Printf(" %s\n", yellow(fmt.Sprintf("— %d pending, run 'mrr db migrate'", pending)))
+	default:
+		fmt.Println()
+	}
+
+	return nil
+}
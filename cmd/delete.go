@@ -10,7 +10,6 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
-	"github.com/indiekitai/mrr-cli/db"
 	"github.com/indiekitai/mrr-cli/models"
 )
 
@@ -33,13 +32,15 @@ func init() {
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	id, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		return fmt.Errorf("invalid ID: %s", args[0])
 	}
 
 	// Get entry first to show what will be deleted
-	entry, err := db.GetEntry(id)
+	entry, err := store.GetEntry(id)
 	if err != nil {
 		return err
 	}
@@ -49,7 +50,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Delete entry #%d: %s from %s on %s? [y/N] ",
 			yellow("⚠"),
 			entry.ID,
-			models.FormatAmount(entry.Amount, "USD"),
+			models.FormatAmount(entry.Amount, entry.Currency),
 			entry.Source,
 			entry.Date.Format("2006-01-02"),
 		)
@@ -64,7 +65,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if err := db.DeleteEntry(id); err != nil {
+	if err := store.DeleteEntry(id); err != nil {
 		return err
 	}
 
@@ -0,0 +1,464 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// defaultChurnMonths is the number of trailing months churn is averaged over
+// to estimate customer lifetime
+const defaultChurnMonths = 6
+
+var (
+	economicsMonth       string
+	economicsMonths      int
+	economicsChurnMonths int
+	economicsJSON        bool
+
+	spendMonth    string
+	spendCurrency string
+	spendNote     string
+	spendJSON     bool
+)
+
+var economicsCmd = &cobra.Command{
+	Use:   "unit-economics",
+	Short: "Show ARPA, LTV, CAC and payback",
+	Long: `Show unit economics for a month and their trend over the months before.
+
+  ARPA       MRR per paying customer
+  ARPU       MRR per active subscription
+  Churn      Average monthly logo churn over the trailing months
+  Lifetime   Expected customer lifetime: 1 / monthly churn
+  LTV        ARPA × lifetime
+  CAC        Acquisition spend / new customers in the month
+  Payback    Months of ARPA it takes to earn back the CAC
+
+CAC needs the month's acquisition spend (ads, marketing, sales), entered
+with 'mrr unit-economics spend set'. All figures come from subscriptions.
+
+Examples:
+  mrr unit-economics                          # This month and the 5 before
+  mrr unit-economics --month 2026-06 --months 12
+  mrr unit-economics --churn-months 3         # Lifetime from recent churn
+  mrr unit-economics spend set 500 --month 2026-09
+  mrr unit-economics --json`,
+	Args: cobra.NoArgs,
+	RunE: runEconomics,
+}
+
+var spendCmd = &cobra.Command{
+	Use:   "spend",
+	Short: "Manage monthly acquisition spend",
+	Long: `Manage what was spent acquiring customers each month, such as ads,
+marketing and sales. It is divided by the month's new customers for CAC.`,
+}
+
+var spendSetCmd = &cobra.Command{
+	Use:   "set <amount>",
+	Short: "Set the acquisition spend for a month",
+	Long: `Set the acquisition spend for a month, replacing any spend already set.
+
+Examples:
+  mrr unit-economics spend set 500                   # Current month
+  mrr unit-economics spend set 450 --month 2026-08 --currency EUR
+  mrr unit-economics spend set 1200 --note "Launch ads"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSpendSet,
+}
+
+var spendListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List acquisition spend",
+	Args:  cobra.NoArgs,
+	RunE:  runSpendList,
+}
+
+var spendDeleteCmd = &cobra.Command{
+	Use:   "delete <month>",
+	Short: "Delete the acquisition spend of a month",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSpendDelete,
+}
+
+func init() {
+	economicsCmd.Flags().StringVarP(&economicsMonth, "month", "m", "", "Last month (YYYY-MM, defaults to current)")
+	economicsCmd.Flags().IntVar(&economicsMonths, "months", 6, "Number of months in the trend")
+	economicsCmd.Flags().IntVar(&economicsChurnMonths, "churn-months", defaultChurnMonths, "Trailing months churn is averaged over")
+	economicsCmd.Flags().BoolVarP(&economicsJSON, "json", "j", false, "Output as JSON")
+
+	spendSetCmd.Flags().StringVarP(&spendMonth, "month", "m", "", "Month (YYYY-MM, defaults to current)")
+	spendSetCmd.Flags().StringVar(&spendCurrency, "currency", "", "ISO currency code (defaults to reporting currency)")
+	spendSetCmd.Flags().StringVarP(&spendNote, "note", "n", "", "Note, e.g. what the money went to")
+	spendListCmd.Flags().BoolVarP(&spendJSON, "json", "j", false, "Output as JSON")
+
+	spendCmd.AddCommand(spendSetCmd)
+	spendCmd.AddCommand(spendListCmd)
+	spendCmd.AddCommand(spendDeleteCmd)
+	economicsCmd.AddCommand(spendCmd)
+}
+
+// unitEconomicsData is a month's unit economics; metrics that can't be
+// computed, such as lifetime without churn or CAC without spend, are nil
+type unitEconomicsData struct {
+	Month            string   `json:"month"`
+	Currency         string   `json:"currency"`
+	PayingCustomers  int      `json:"paying_customers"`
+	Subscriptions    int      `json:"subscriptions"`
+	NewCustomers     int      `json:"new_customers"`
+	ARPA             *float64 `json:"arpa,omitempty"`
+	ARPU             *float64 `json:"arpu,omitempty"`
+	ChurnMonths      int      `json:"churn_months"`
+	MonthlyChurnRate *float64 `json:"monthly_churn_rate,omitempty"` // Percent
+	Lifetime         *float64 `json:"lifetime_months,omitempty"`
+	LTV              *float64 `json:"ltv,omitempty"`
+	AcquisitionSpend *float64 `json:"acquisition_spend,omitempty"`
+	CAC              *float64 `json:"cac,omitempty"`
+	PaybackMonths    *float64 `json:"payback_months,omitempty"`
+	LTVToCAC         *float64 `json:"ltv_to_cac,omitempty"`
+}
+
+func newUnitEconomicsData(u *db.UnitEconomics, currency string) unitEconomicsData {
+	data := unitEconomicsData{
+		Month:           u.Month,
+		Currency:        currency,
+		PayingCustomers: u.PayingCustomers,
+		Subscriptions:   u.Subscriptions,
+		NewCustomers:    u.NewCustomers,
+	}
+	amount := func(cents int64) *float64 {
+		v := float64(cents) / 100.0
+		return &v
+	}
+	value := func(v float64) *float64 {
+		return &v
+	}
+
+	if u.PayingCustomers > 0 {
+		data.ARPA = amount(u.ARPA())
+		data.ARPU = amount(u.ARPU())
+	}
+	data.ChurnMonths = u.ChurnMonths
+	if u.CustomerMonths > 0 {
+		data.MonthlyChurnRate = value(u.MonthlyChurnRate() * 100)
+	}
+	if u.Lifetime() > 0 {
+		data.Lifetime = value(u.Lifetime())
+		if u.PayingCustomers > 0 {
+			data.LTV = amount(u.LTV())
+		}
+	}
+	if u.HasSpend {
+		data.AcquisitionSpend = amount(u.AcquisitionSpend)
+		if u.NewCustomers > 0 {
+			data.CAC = amount(u.CAC())
+			if u.ARPA() > 0 {
+				data.PaybackMonths = value(u.PaybackMonths())
+			}
+			if data.LTV != nil && u.CAC() > 0 {
+				data.LTVToCAC = value(float64(u.LTV()) / float64(u.CAC()))
+			}
+		}
+	}
+
+	return data
+}
+
+// buildUnitEconomics computes the unit economics of a month from s
+func buildUnitEconomics(s db.Store, month string, churnMonths int) (unitEconomicsData, error) {
+	u, err := db.GetUnitEconomics(s, month, churnMonths)
+	if err != nil {
+		return unitEconomicsData{}, err
+	}
+	return newUnitEconomicsData(u, s.ReportingCurrency()), nil
+}
+
+type economicsOutput struct {
+	unitEconomicsData
+	Trend []unitEconomicsData `json:"trend"`
+}
+
+func runEconomics(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	month := economicsMonth
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+	if economicsMonths < 1 {
+		return fmt.Errorf("--months must be at least 1")
+	}
+	if economicsChurnMonths < 1 {
+		return fmt.Errorf("--churn-months must be at least 1")
+	}
+
+	output := economicsOutput{Trend: []unitEconomicsData{}}
+	for i := economicsMonths - 1; i >= 0; i-- {
+		data, err := buildUnitEconomics(store, t.AddDate(0, -i, 0).Format("2006-01"), economicsChurnMonths)
+		if err != nil {
+			return err
+		}
+		output.Trend = append(output.Trend, data)
+	}
+	output.unitEconomicsData = output.Trend[len(output.Trend)-1]
+
+	if economicsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("Unit Economics: %s", t.Format("January 2006"))))
+	fmt.Println("  " + strings.Repeat("─", 35))
+	fmt.Println()
+
+	if output.PayingCustomers == 0 {
+		fmt.Printf("  %s No paying customers this month. Unit economics are computed from subscriptions ('mrr sub add').\n\n", yellow("⚠"))
+		return nil
+	}
+
+	printUnitEconomics(output.unitEconomicsData)
+	fmt.Println()
+
+	if len(output.Trend) > 1 {
+		printUnitEconomicsTrend(output.Trend)
+	}
+
+	return nil
+}
+
+// printUnitEconomics prints a month's unit economics, one metric per line
+func printUnitEconomics(u unitEconomicsData) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	fmt.Printf("    Customers:  %d paying, %d new\n", u.PayingCustomers, u.NewCustomers)
+	fmt.Printf("    ARPA:       %s per customer\n", green(formatOptionalAmount(u.ARPA, u.Currency)))
+	fmt.Printf("    ARPU:       %s per subscription\n", green(formatOptionalAmount(u.ARPU, u.Currency)))
+	if u.MonthlyChurnRate != nil {
+		fmt.Printf("    Churn:      %s per month (trailing %d months)\n", formatRate(u.MonthlyChurnRate), u.ChurnMonths)
+	} else {
+		fmt.Printf("    Churn:      — (no customers in the trailing %d months)\n", u.ChurnMonths)
+	}
+	if u.Lifetime != nil {
+		fmt.Printf("    Lifetime:   %.1f months\n", *u.Lifetime)
+		fmt.Printf("    LTV:        %s\n", green(formatOptionalAmount(u.LTV, u.Currency)))
+	} else {
+		fmt.Printf("    Lifetime:   — (no churn to estimate it from)\n")
+		fmt.Printf("    LTV:        —\n")
+	}
+
+	if u.AcquisitionSpend == nil {
+		fmt.Printf("    CAC:        — (set the month's spend with 'mrr unit-economics spend set')\n")
+		return
+	}
+	fmt.Printf("    Spend:      %s\n", formatOptionalAmount(u.AcquisitionSpend, u.Currency))
+	fmt.Printf("    CAC:        %s\n", yellow(formatOptionalAmount(u.CAC, u.Currency)))
+	if u.PaybackMonths != nil {
+		fmt.Printf("    Payback:    %s\n", formatMonths(u.PaybackMonths))
+	}
+	if u.LTVToCAC != nil {
+		ratio := fmt.Sprintf("%.1fx", *u.LTVToCAC)
+		if *u.LTVToCAC >= 3 {
+			ratio = green(ratio)
+		} else if *u.LTVToCAC < 1 {
+			ratio = red(ratio)
+		} else {
+			ratio = yellow(ratio)
+		}
+		fmt.Printf("    LTV:CAC:    %s\n", ratio)
+	}
+}
+
+// printUnitEconomicsTrend prints one row of unit economics per month
+func printUnitEconomicsTrend(trend []unitEconomicsData) {
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Printf("  %s\n", bold("Trend:"))
+
+	header := []string{"Month", "Customers", "New", "ARPA", "Churn/mo", "Lifetime", "LTV", "CAC", "Payback"}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, len(header))
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, u := range trend {
+		table.Rich([]string{
+			u.Month,
+			strconv.Itoa(u.PayingCustomers),
+			strconv.Itoa(u.NewCustomers),
+			formatOptionalAmount(u.ARPA, u.Currency),
+			formatRate(u.MonthlyChurnRate),
+			formatMonths(u.Lifetime),
+			formatOptionalAmount(u.LTV, u.Currency),
+			formatOptionalAmount(u.CAC, u.Currency),
+			formatMonths(u.PaybackMonths),
+		}, []tablewriter.Colors{
+			{tablewriter.FgBlueColor},
+			{},
+			{},
+			{tablewriter.FgGreenColor},
+			{},
+			{},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgYellowColor},
+			{},
+		})
+	}
+
+	table.Render()
+	fmt.Println()
+}
+
+// formatOptionalAmount formats an amount, or a dash if there is none
+func formatOptionalAmount(amount *float64, currency string) string {
+	if amount == nil {
+		return "—"
+	}
+	return models.FormatAmount(int64(math.Round(*amount*100)), currency)
+}
+
+// formatMonths formats a number of months, or a dash if there is none
+func formatMonths(months *float64) string {
+	if months == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f mo", *months)
+}
+
+func runSpendSet(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	amountStr := strings.TrimPrefix(args[0], "$")
+	amountFloat, err := strconv.ParseFloat(amountStr, 64)
+	if err != nil || amountFloat < 0 {
+		return fmt.Errorf("invalid amount: %s", args[0])
+	}
+	amountCents := int64(math.Round(amountFloat * 100))
+
+	month := spendMonth
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+
+	currency, err := parseCurrency(store, spendCurrency)
+	if err != nil {
+		return err
+	}
+
+	if err := store.SetAcquisitionSpend(month, amountCents, currency, spendNote); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Acquisition spend for %s: %s\n", green("✓"), month, models.FormatAmount(amountCents, currency))
+
+	return nil
+}
+
+type spendEntry struct {
+	Month    string  `json:"month"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Note     string  `json:"note,omitempty"`
+}
+
+func runSpendList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	spend, err := store.ListAcquisitionSpend()
+	if err != nil {
+		return err
+	}
+
+	if spendJSON {
+		output := []spendEntry{}
+		for _, sp := range spend {
+			output = append(output, spendEntry{
+				Month:    sp.Month,
+				Amount:   float64(sp.Amount) / 100.0,
+				Currency: sp.Currency,
+				Note:     sp.Note,
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(spend) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No acquisition spend set. Add some with 'mrr unit-economics spend set <amount>'.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Month", "Spend", "Note"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, sp := range spend {
+		table.Rich([]string{
+			sp.Month,
+			models.FormatAmount(sp.Amount, sp.Currency),
+			sp.Note,
+		}, []tablewriter.Colors{
+			{tablewriter.FgBlueColor},
+			{tablewriter.FgYellowColor},
+			{},
+		})
+	}
+
+	table.Render()
+
+	return nil
+}
+
+func runSpendDelete(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	month := args[0]
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+
+	if err := store.DeleteAcquisitionSpend(month); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Deleted acquisition spend for %s\n", green("✓"), month)
+
+	return nil
+}
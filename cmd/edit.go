@@ -3,19 +3,19 @@ package cmd
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
-	"github.com/indiekitai/mrr-cli/db"
 	"github.com/indiekitai/mrr-cli/models"
 )
 
 var (
-	editAmount string
-	editSource string
-	editNote   string
+	editAmount   string
+	editSource   string
+	editNote     string
+	editCustomer string
+	editCurrency string
 )
 
 var editCmd = &cobra.Command{
@@ -27,7 +27,10 @@ Examples:
   mrr edit 1 --amount 49.99
   mrr edit 1 --source stripe
   mrr edit 1 --note "Updated note"
-  mrr edit 1 --amount 99 --source gumroad`,
+  mrr edit 1 --amount 99 --source gumroad
+  mrr edit 1 --customer acme
+  mrr edit 1 --customer ""          # Unlink customer
+  mrr edit 1 --currency EUR`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEdit,
 }
@@ -36,27 +39,37 @@ func init() {
 	editCmd.Flags().StringVarP(&editAmount, "amount", "a", "", "New amount")
 	editCmd.Flags().StringVarP(&editSource, "source", "s", "", "New source")
 	editCmd.Flags().StringVarP(&editNote, "note", "n", "", "New note")
+	editCmd.Flags().StringVar(&editCurrency, "currency", "", "New ISO currency code")
+	editCmd.Flags().StringVarP(&editCustomer, "customer", "c", "", "New customer name or ID (empty to unlink)")
 }
 
 func runEdit(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	id, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		return fmt.Errorf("invalid ID: %s", args[0])
 	}
 
 	var amount *int64
-	var source, note *string
+	var currency, source, note *string
 
 	if editAmount != "" {
-		amountStr := strings.TrimPrefix(editAmount, "$")
-		amountFloat, err := strconv.ParseFloat(amountStr, 64)
+		amountCents, err := parseAmount(editAmount)
 		if err != nil {
-			return fmt.Errorf("invalid amount: %s", editAmount)
+			return err
 		}
-		amountCents := int64(amountFloat * 100)
 		amount = &amountCents
 	}
 
+	if editCurrency != "" {
+		c, err := parseCurrency(store, editCurrency)
+		if err != nil {
+			return err
+		}
+		currency = &c
+	}
+
 	if editSource != "" {
 		if !models.IsValidSource(editSource) {
 			return fmt.Errorf("invalid source: %s (valid: %v)", editSource, models.ValidSources)
@@ -68,11 +81,20 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		note = &editNote
 	}
 
-	if amount == nil && source == nil && note == nil {
-		return fmt.Errorf("no fields to update (use --amount, --source, or --note)")
+	var customerID *int64
+	if cmd.Flags().Changed("customer") {
+		cid, err := resolveCustomer(store, editCustomer)
+		if err != nil {
+			return err
+		}
+		customerID = &cid
+	}
+
+	if amount == nil && currency == nil && source == nil && note == nil && customerID == nil {
+		return fmt.Errorf("no fields to update (use --amount, --currency, --source, --note, or --customer)")
 	}
 
-	if err := db.UpdateEntry(id, amount, source, note); err != nil {
+	if err := store.UpdateEntry(id, amount, currency, source, note, customerID); err != nil {
 		return err
 	}
 
@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	expenseAddCategory string
+	expenseAddInterval string
+	expenseCategory    string
+	expenseInterval    string
+	expenseNote        string
+	expenseDate        string
+	expenseEnd         string
+	expenseCurrency    string
+	expenseAmount      string
+	expenseMonth       string
+	expenseJSON        bool
+	expenseForce       bool
+)
+
+var expenseCmd = &cobra.Command{
+	Use:   "expense",
+	Short: "Manage expenses",
+	Long: `Manage business expenses. Expenses are set against revenue in 'mrr pnl'
+to report profit, margin, burn and runway.
+
+One-time expenses count in the month of their date. Recurring expenses are
+charged every month (or every year, in the month of their date) from their
+date until their end date, if they have one.
+
+Examples:
+  mrr expense add 20 --category hosting --interval month
+  mrr expense add 1200 --category software --interval year --note "IDE licences"
+  mrr expense add 350 --category contractors --date 2026-09-14
+  mrr expense edit 3 --end 2026-12-31      # Stop a recurring expense
+  mrr expense list --month 2026-09`,
+}
+
+var expenseAddCmd = &cobra.Command{
+	Use:   "add <amount>",
+	Short: "Add an expense",
+	Long: fmt.Sprintf(`Add an expense. Amount is in dollars per charge.
+
+Categories: %s
+
+Examples:
+  mrr expense add 49.99 --category software
+  mrr expense add 20 --category hosting --interval month
+  mrr expense add 99 --category software --interval year --date 2026-03-01
+  mrr expense add 500 --category marketing --currency EUR --note "Newsletter ad"`, strings.Join(models.ValidExpenseCategories, ", ")),
+	Args: cobra.ExactArgs(1),
+	RunE: runExpenseAdd,
+}
+
+var expenseListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List expenses",
+	Long: `List expenses.
+
+Examples:
+  mrr expense list
+  mrr expense list --month 2026-09      # Expenses charged in September
+  mrr expense list --category hosting
+  mrr expense list --json`,
+	Args: cobra.NoArgs,
+	RunE: runExpenseList,
+}
+
+var expenseEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edit an expense",
+	Long: `Edit an existing expense by ID.
+
+Examples:
+  mrr expense edit 1 --amount 25
+  mrr expense edit 1 --category software --note "Renamed plan"
+  mrr expense edit 1 --end 2026-12-31    # Last charge of a recurring expense
+  mrr expense edit 1 --end ""            # Continue indefinitely again`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpenseEdit,
+}
+
+var expenseDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete an expense",
+	Long: `Delete an expense by ID.
+
+Examples:
+  mrr expense delete 1
+  mrr expense delete 1 -f  # Skip confirmation`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpenseDelete,
+}
+
+func init() {
+	expenseAddCmd.Flags().StringVarP(&expenseAddCategory, "category", "c", "other", "Expense category")
+	expenseAddCmd.Flags().StringVarP(&expenseAddInterval, "interval", "i", "once", "How often it is charged (once, month, year)")
+	expenseAddCmd.Flags().StringVarP(&expenseDate, "date", "d", "", "Date, or first charge if recurring (YYYY-MM-DD, defaults to today)")
+	expenseAddCmd.Flags().StringVar(&expenseEnd, "end", "", "Last charge of a recurring expense (YYYY-MM-DD)")
+	expenseAddCmd.Flags().StringVar(&expenseCurrency, "currency", "", "ISO currency code (defaults to reporting currency)")
+	expenseAddCmd.Flags().StringVarP(&expenseNote, "note", "n", "", "Note for this expense")
+
+	expenseListCmd.Flags().StringVarP(&expenseMonth, "month", "m", "", "Only expenses charged in this month (YYYY-MM)")
+	expenseListCmd.Flags().StringVarP(&expenseCategory, "category", "c", "", "Filter by category")
+	expenseListCmd.Flags().BoolVarP(&expenseJSON, "json", "j", false, "Output as JSON")
+
+	expenseEditCmd.Flags().StringVarP(&expenseAmount, "amount", "a", "", "New amount")
+	expenseEditCmd.Flags().StringVarP(&expenseCategory, "category", "c", "", "New category")
+	expenseEditCmd.Flags().StringVarP(&expenseInterval, "interval", "i", "", "New interval (once, month, year)")
+	expenseEditCmd.Flags().StringVarP(&expenseDate, "date", "d", "", "New date (YYYY-MM-DD)")
+	expenseEditCmd.Flags().StringVar(&expenseEnd, "end", "", "New last charge (YYYY-MM-DD, empty to continue indefinitely)")
+	expenseEditCmd.Flags().StringVar(&expenseCurrency, "currency", "", "New ISO currency code")
+	expenseEditCmd.Flags().StringVarP(&expenseNote, "note", "n", "", "New note")
+
+	expenseDeleteCmd.Flags().BoolVarP(&expenseForce, "force", "f", false, "Skip confirmation")
+
+	expenseCmd.AddCommand(expenseAddCmd)
+	expenseCmd.AddCommand(expenseListCmd)
+	expenseCmd.AddCommand(expenseEditCmd)
+	expenseCmd.AddCommand(expenseDeleteCmd)
+}
+
+type expenseEntry struct {
+	ID       int64   `json:"id"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Category string  `json:"category"`
+	Interval string  `json:"interval"`
+	Date     string  `json:"date"`
+	EndDate  string  `json:"end_date,omitempty"`
+	Note     string  `json:"note,omitempty"`
+}
+
+type expenseListOutput struct {
+	Expenses       []expenseEntry `json:"expenses"`
+	Month          string         `json:"month,omitempty"`
+	Total          *float64       `json:"total,omitempty"` // Charged in the month, with --month
+	RecurringCosts float64        `json:"recurring_costs"` // Per month, of expenses running today
+	Currency       string         `json:"currency"`
+	Count          int            `json:"count"`
+}
+
+func runExpenseAdd(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	amountCents, err := parsePositiveAmount(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !models.IsValidExpenseCategory(expenseAddCategory) {
+		return fmt.Errorf("invalid category: %s (valid: %v)", expenseAddCategory, models.ValidExpenseCategories)
+	}
+	if !models.IsValidExpenseInterval(expenseAddInterval) {
+		return fmt.Errorf("invalid interval: %s (valid: %v)", expenseAddInterval, models.ValidExpenseIntervals)
+	}
+
+	currency, err := parseCurrency(store, expenseCurrency)
+	if err != nil {
+		return err
+	}
+
+	date := time.Now()
+	if expenseDate != "" {
+		date, err = time.Parse("2006-01-02", expenseDate)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", expenseDate)
+		}
+	}
+
+	var endDate *time.Time
+	if expenseEnd != "" {
+		if expenseAddInterval == "once" {
+			return fmt.Errorf("--end only applies to recurring expenses (use --interval month or year)")
+		}
+		end, err := time.Parse("2006-01-02", expenseEnd)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", expenseEnd)
+		}
+		if end.Before(date) {
+			return fmt.Errorf("end date %s is before the first charge on %s", expenseEnd, date.Format("2006-01-02"))
+		}
+		endDate = &end
+	}
+
+	id, err := store.AddExpense(amountCents, currency, expenseAddCategory, expenseAddInterval, expenseNote, date, endDate)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	charge := models.FormatAmount(amountCents, currency)
+	if expenseAddInterval != "once" {
+		charge += "/" + expenseAddInterval
+	}
+	fmt.Printf("%s Added expense #%s: %s for %s\n",
+		green("✓"),
+		cyan(fmt.Sprintf("%d", id)),
+		charge,
+		expenseAddCategory,
+	)
+
+	return nil
+}
+
+func runExpenseList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	if expenseCategory != "" && !models.IsValidExpenseCategory(expenseCategory) {
+		return fmt.Errorf("invalid category: %s (valid: %v)", expenseCategory, models.ValidExpenseCategories)
+	}
+
+	expenses, err := store.ListExpenses(expenseCategory)
+	if err != nil {
+		return err
+	}
+
+	// Monthly cost of the recurring expenses still running
+	now := time.Now()
+	var running []models.Expense
+	for _, e := range expenses {
+		if e.IsRecurring() && !e.Date.After(now) && (e.EndDate == nil || !e.EndDate.Before(now)) {
+			running = append(running, e)
+		}
+	}
+	recurring, err := convertExpenses(store, running, now.Format("2006-01"), func(e models.Expense) int64 { return e.MonthlyAmount() })
+	if err != nil {
+		return err
+	}
+
+	var total *int64
+	if expenseMonth != "" {
+		month, err := time.Parse("2006-01", expenseMonth)
+		if err != nil {
+			return fmt.Errorf("invalid month format, use YYYY-MM: %s", expenseMonth)
+		}
+		var charged []models.Expense
+		for _, e := range expenses {
+			if e.IsChargedIn(month) {
+				charged = append(charged, e)
+			}
+		}
+		expenses = charged
+
+		sum, err := convertExpenses(store, expenses, expenseMonth, func(e models.Expense) int64 { return e.Amount })
+		if err != nil {
+			return err
+		}
+		total = &sum
+	}
+
+	if expenseJSON {
+		output := expenseListOutput{
+			Expenses:       []expenseEntry{},
+			Month:          expenseMonth,
+			RecurringCosts: float64(recurring) / 100.0,
+			Currency:       store.ReportingCurrency(),
+			Count:          len(expenses),
+		}
+		if total != nil {
+			t := float64(*total) / 100.0
+			output.Total = &t
+		}
+		for _, e := range expenses {
+			entry := expenseEntry{
+				ID:       e.ID,
+				Amount:   float64(e.Amount) / 100.0,
+				Currency: e.Currency,
+				Category: e.Category,
+				Interval: e.Interval,
+				Date:     e.Date.Format("2006-01-02"),
+				Note:     e.Note,
+			}
+			if e.EndDate != nil {
+				entry.EndDate = e.EndDate.Format("2006-01-02")
+			}
+			output.Expenses = append(output.Expenses, entry)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(expenses) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No expenses found.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Date", "Category", "Amount", "Every", "Ends", "Note"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, e := range expenses {
+		every, ends := "", ""
+		if e.IsRecurring() {
+			every = e.Interval
+		}
+		if e.EndDate != nil {
+			ends = e.EndDate.Format("2006-01-02")
+		}
+
+		note := e.Note
+		if len(note) > 30 {
+			note = note[:27] + "..."
+		}
+
+		table.Rich([]string{
+			fmt.Sprintf("%d", e.ID),
+			e.Date.Format("2006-01-02"),
+			e.Category,
+			models.FormatAmount(e.Amount, e.Currency),
+			every,
+			ends,
+			note,
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{tablewriter.FgMagentaColor},
+			{tablewriter.FgRedColor},
+			{},
+			{},
+			{},
+		})
+	}
+
+	table.Render()
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	currency := store.ReportingCurrency()
+	fmt.Println()
+	if total != nil {
+		fmt.Printf("%s expenses charged in %s: %s\n", cyan(fmt.Sprintf("%d", len(expenses))), expenseMonth, red(models.FormatAmount(*total, currency)))
+	} else {
+		fmt.Printf("%s expenses\n", cyan(fmt.Sprintf("%d", len(expenses))))
+	}
+	if recurring > 0 {
+		fmt.Printf("Recurring costs: %s/month\n", red(models.FormatAmount(recurring, currency)))
+	}
+
+	return nil
+}
+
+// convertExpenses totals amount(e) over expenses in the reporting currency
+// at a month's rates
+func convertExpenses(s db.Store, expenses []models.Expense, month string, amount func(models.Expense) int64) (int64, error) {
+	fx, err := db.LoadFXTable(s)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, e := range expenses {
+		converted, err := fx.Convert(amount(e), e.Currency, s.ReportingCurrency(), month)
+		if err != nil {
+			return 0, err
+		}
+		total += converted
+	}
+	return total, nil
+}
+
+func runExpenseEdit(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID: %s", args[0])
+	}
+
+	var amount *int64
+	var currency, category, interval, note *string
+	var date, endDate *time.Time
+
+	if expenseAmount != "" {
+		amountCents, err := parsePositiveAmount(expenseAmount)
+		if err != nil {
+			return err
+		}
+		amount = &amountCents
+	}
+
+	if expenseCurrency != "" {
+		c, err := parseCurrency(store, expenseCurrency)
+		if err != nil {
+			return err
+		}
+		currency = &c
+	}
+
+	if expenseCategory != "" {
+		if !models.IsValidExpenseCategory(expenseCategory) {
+			return fmt.Errorf("invalid category: %s (valid: %v)", expenseCategory, models.ValidExpenseCategories)
+		}
+		category = &expenseCategory
+	}
+
+	if expenseInterval != "" {
+		if !models.IsValidExpenseInterval(expenseInterval) {
+			return fmt.Errorf("invalid interval: %s (valid: %v)", expenseInterval, models.ValidExpenseIntervals)
+		}
+		interval = &expenseInterval
+	}
+
+	if cmd.Flags().Changed("note") {
+		note = &expenseNote
+	}
+
+	if expenseDate != "" {
+		d, err := time.Parse("2006-01-02", expenseDate)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", expenseDate)
+		}
+		date = &d
+	}
+
+	if cmd.Flags().Changed("end") {
+		var end time.Time
+		if expenseEnd != "" {
+			end, err = time.Parse("2006-01-02", expenseEnd)
+			if err != nil {
+				return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", expenseEnd)
+			}
+		}
+		endDate = &end
+	}
+
+	if amount == nil && currency == nil && category == nil && interval == nil && note == nil && date == nil && endDate == nil {
+		return fmt.Errorf("no fields to update (use --amount, --currency, --category, --interval, --date, --end, or --note)")
+	}
+
+	if err := store.UpdateExpense(id, amount, currency, category, interval, note, date, endDate); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s Updated expense #%s\n", green("✓"), cyan(fmt.Sprintf("%d", id)))
+
+	return nil
+}
+
+func runExpenseDelete(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID: %s", args[0])
+	}
+
+	// Get expense first to show what will be deleted
+	expense, err := store.GetExpense(id)
+	if err != nil {
+		return err
+	}
+
+	if !expenseForce {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s Delete expense #%d: %s for %s on %s? [y/N] ",
+			yellow("⚠"),
+			expense.ID,
+			models.FormatAmount(expense.Amount, expense.Currency),
+			expense.Category,
+			expense.Date.Format("2006-01-02"),
+		)
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if err := store.DeleteExpense(id); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Deleted expense #%d\n", green("✓"), id)
+
+	return nil
+}
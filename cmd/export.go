@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/indiekitai/mrr-cli/db"
 	"github.com/indiekitai/mrr-cli/models"
 )
 
@@ -38,15 +37,19 @@ func init() {
 }
 
 type exportEntry struct {
-	Date   string  `json:"date"`
-	Amount float64 `json:"amount"`
-	Source string  `json:"source"`
-	Type   string  `json:"type"`
-	Note   string  `json:"note"`
+	Date     string  `json:"date"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Source   string  `json:"source"`
+	Type     string  `json:"type"`
+	Note     string  `json:"note"`
+	Customer string  `json:"customer,omitempty"`
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	entries, err := db.ListEntries(exportMonth, "", "")
+	store := storeFrom(cmd)
+
+	entries, err := store.ListEntries(exportMonth, "", "", 0)
 	if err != nil {
 		return err
 	}
@@ -62,11 +65,13 @@ func exportAsJSON(entries []models.Entry) error {
 	var exportEntries []exportEntry
 	for _, e := range entries {
 		exportEntries = append(exportEntries, exportEntry{
-			Date:   e.Date.Format("2006-01-02"),
-			Amount: float64(e.Amount) / 100.0,
-			Source: e.Source,
-			Type:   e.Type,
-			Note:   e.Note,
+			Date:     e.Date.Format("2006-01-02"),
+			Amount:   float64(e.Amount) / 100.0,
+			Currency: e.Currency,
+			Source:   e.Source,
+			Type:     e.Type,
+			Note:     e.Note,
+			Customer: e.CustomerName,
 		})
 	}
 
@@ -104,7 +109,7 @@ func exportAsCSV(entries []models.Entry) error {
 	defer writer.Flush()
 
 	// Write header
-	if err := writer.Write([]string{"date", "amount", "source", "type", "note"}); err != nil {
+	if err := writer.Write([]string{"date", "amount", "source", "type", "note", "customer", "currency"}); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
@@ -116,6 +121,8 @@ func exportAsCSV(entries []models.Entry) error {
 			e.Source,
 			e.Type,
 			e.Note,
+			e.CustomerName,
+			e.Currency,
 		}
 		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("failed to write record: %w", err)
@@ -34,6 +34,7 @@ func init() {
 }
 
 type forecastData struct {
+	Currency     string             `json:"currency"`
 	CurrentMRR   float64            `json:"current_mrr"`
 	GrowthRate   float64            `json:"growth_rate"`
 	Projections  map[string]float64 `json:"projections"`
@@ -48,18 +49,36 @@ type milestoneData struct {
 }
 
 func runForecast(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	data, err := buildForecast(store)
+	if err != nil {
+		return err
+	}
+
+	if forecastJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	return printForecast(data)
+}
+
+// buildForecast projects MRR from s at the current month's growth rate
+func buildForecast(s db.Store) (forecastData, error) {
 	currentMonth := time.Now().Format("2006-01")
 
 	// Get current MRR
-	report, err := db.GetMonthlyReport(currentMonth)
+	report, err := db.GetMonthlyReport(s, currentMonth)
 	if err != nil {
-		return err
+		return forecastData{}, err
 	}
 
 	currentMRR := float64(report.RecurringRevenue) / 100.0
 
 	// Get previous month MRR for growth rate
-	prevMRR, err := db.GetPreviousMonthMRR(currentMonth)
+	prevMRR, err := db.GetPreviousMonthMRR(s, currentMonth)
 	if err != nil {
 		prevMRR = 0
 	}
@@ -73,7 +92,7 @@ func runForecast(cmd *cobra.Command, args []string) error {
 
 	// Calculate projections
 	monthlyGrowth := 1 + (growthRate / 100)
-	
+
 	// Cap projections at reasonable values (1 billion)
 	maxProjection := 1000000000.0
 	capProjection := func(val float64) float64 {
@@ -82,7 +101,7 @@ func runForecast(cmd *cobra.Command, args []string) error {
 		}
 		return val
 	}
-	
+
 	projections := map[string]float64{
 		"3_months":  capProjection(currentMRR * math.Pow(monthlyGrowth, 3)),
 		"6_months":  capProjection(currentMRR * math.Pow(monthlyGrowth, 6)),
@@ -117,6 +136,7 @@ func runForecast(cmd *cobra.Command, args []string) error {
 	}
 
 	data := forecastData{
+		Currency:     report.Currency,
 		CurrentMRR:   currentMRR,
 		GrowthRate:   growthRate,
 		Projections:  projections,
@@ -124,13 +144,7 @@ func runForecast(cmd *cobra.Command, args []string) error {
 		BasedOnMonth: currentMonth,
 	}
 
-	if forecastJSON {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(data)
-	}
-
-	return printForecast(data)
+	return data, nil
 }
 
 func printForecast(data forecastData) error {
@@ -153,7 +167,7 @@ func printForecast(data forecastData) error {
 	fmt.Println("  " + strings.Repeat("─", 44))
 	fmt.Println()
 
-	fmt.Printf("  %s   %s\n", bold("Current:"), green(models.FormatAmount(int64(data.CurrentMRR*100), "USD")))
+	fmt.Printf("  %s   %s\n", bold("Current:"), green(models.FormatAmount(int64(data.CurrentMRR*100), data.Currency)))
 
 	if data.GrowthRate == 0 {
 		fmt.Printf("\n  %s No growth data available for projections.\n", yellow("⚠"))
@@ -162,16 +176,16 @@ func printForecast(data forecastData) error {
 		return nil
 	}
 
-	fmt.Printf("  %s %s\n", bold("In 3 months:"), formatProjection(data.Projections["3_months"]))
-	fmt.Printf("  %s %s\n", bold("In 6 months:"), formatProjection(data.Projections["6_months"]))
-	fmt.Printf("  %s%s\n", bold("In 12 months:"), formatProjection(data.Projections["12_months"]))
+	fmt.Printf("  %s %s\n", bold("In 3 months:"), formatProjection(data.Projections["3_months"], data.Currency))
+	fmt.Printf("  %s %s\n", bold("In 6 months:"), formatProjection(data.Projections["6_months"], data.Currency))
+	fmt.Printf("  %s%s\n", bold("In 12 months:"), formatProjection(data.Projections["12_months"], data.Currency))
 	fmt.Println()
 
 	if len(data.Milestones) > 0 {
 		fmt.Printf("  %s\n", bold("Milestones:"))
 		for _, m := range data.Milestones {
 			fmt.Printf("    %s MRR: ~%d months (%s)\n",
-				green(models.FormatAmount(int64(m.Target*100), "USD")),
+				green(models.FormatAmount(int64(m.Target*100), data.Currency)),
 				m.MonthsAway,
 				yellow(m.EstimatedDate),
 			)
@@ -186,6 +200,6 @@ func printForecast(data forecastData) error {
 	return nil
 }
 
-func formatProjection(amount float64) string {
-	return models.FormatAmount(int64(amount*100), "USD")
+func formatProjection(amount float64, currency string) string {
+	return models.FormatAmount(int64(amount*100), currency)
 }
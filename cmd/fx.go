@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	fxDate string
+	fxJSON bool
+)
+
+var fxCmd = &cobra.Command{
+	Use:   "fx",
+	Short: "Manage currencies and FX rates",
+	Long: `Manage the reporting currency and the local FX rate table.
+
+Every entry and subscription stores its own currency. Reports, forecasts,
+goals, badges and the dashboard convert amounts into the reporting currency
+using the rate for the month the revenue falls in (or the latest earlier one).
+
+Rates are the value of one unit of the currency in USD.
+
+Examples:
+  mrr fx currency EUR                 # Report in EUR
+  mrr fx set EUR 1.08 --date 2026-01  # 1 EUR = 1.08 USD from January 2026
+  mrr fx import rates.csv
+  mrr fx list`,
+}
+
+var fxSetCmd = &cobra.Command{
+	Use:   "set <currency> <rate>",
+	Short: "Set an FX rate for a month",
+	Long: `Set the USD value of one unit of a currency for a month.
+
+Examples:
+  mrr fx set EUR 1.08                 # Current month
+  mrr fx set GBP 1.27 --date 2026-01`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFXSet,
+}
+
+var fxImportCmd = &cobra.Command{
+	Use:   "import <rates.csv>",
+	Short: "Import FX rates from CSV",
+	Long: `Import FX rates from a CSV file.
+
+CSV format:
+  currency,month,rate
+  EUR,2026-01,1.08
+  GBP,2026-01,1.27
+
+Examples:
+  mrr fx import rates.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFXImport,
+}
+
+var fxListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List FX rates",
+	RunE:  runFXList,
+}
+
+var fxCurrencyCmd = &cobra.Command{
+	Use:   "currency [code]",
+	Short: "Show or set the reporting currency",
+	Long: `Show or set the reporting currency. It is stored in config.json and used
+as the default currency for new entries.
+
+Examples:
+  mrr fx currency         # Show reporting currency
+  mrr fx currency EUR     # Report in EUR`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFXCurrency,
+}
+
+func init() {
+	fxSetCmd.Flags().StringVarP(&fxDate, "date", "d", "", "Month the rate applies from (YYYY-MM, defaults to current)")
+	fxListCmd.Flags().BoolVarP(&fxJSON, "json", "j", false, "Output as JSON")
+
+	fxCmd.AddCommand(fxSetCmd)
+	fxCmd.AddCommand(fxImportCmd)
+	fxCmd.AddCommand(fxListCmd)
+	fxCmd.AddCommand(fxCurrencyCmd)
+}
+
+// parseCurrency normalizes and validates a currency code, defaulting to the
+// reporting currency when empty
+func parseCurrency(s db.Store, code string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(code))
+	if currency == "" {
+		return s.ReportingCurrency(), nil
+	}
+	if !models.IsValidCurrency(currency) {
+		return "", fmt.Errorf("invalid currency: %s (use an ISO code like USD, EUR)", code)
+	}
+	return currency, nil
+}
+
+// parseFXRate validates a currency/month/rate triple
+func parseFXRate(s db.Store, currencyStr, month, rateStr string) (models.FXRate, error) {
+	currency, err := parseCurrency(s, currencyStr)
+	if err != nil {
+		return models.FXRate{}, err
+	}
+	if currency == models.BaseCurrency {
+		return models.FXRate{}, fmt.Errorf("%s is the base currency, its rate is always 1", models.BaseCurrency)
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return models.FXRate{}, fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+	if err != nil || rate <= 0 {
+		return models.FXRate{}, fmt.Errorf("invalid rate: %s", rateStr)
+	}
+	return models.FXRate{Currency: currency, Month: month, Rate: rate}, nil
+}
+
+func runFXSet(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	month := fxDate
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+
+	rate, err := parseFXRate(store, args[0], month, args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := store.SetFXRate(rate.Currency, rate.Month, rate.Rate); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s 1 %s = %g %s from %s\n", green("✓"), rate.Currency, rate.Rate, models.BaseCurrency, rate.Month)
+
+	return nil
+}
+
+func runFXImport(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return fmt.Errorf("CSV file is empty or has only headers")
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	imported := 0
+	skipped := 0
+
+	for i, record := range records[1:] {
+		lineNum := i + 2
+
+		if len(record) < 3 {
+			fmt.Printf("%s Line %d: insufficient fields, skipping\n", yellow("⚠"), lineNum)
+			skipped++
+			continue
+		}
+
+		rate, err := parseFXRate(store, record[0], strings.TrimSpace(record[1]), record[2])
+		if err != nil {
+			fmt.Printf("%s Line %d: %v, skipping\n", red("✗"), lineNum, err)
+			skipped++
+			continue
+		}
+
+		if err := store.SetFXRate(rate.Currency, rate.Month, rate.Rate); err != nil {
+			fmt.Printf("%s Line %d: %v\n", red("✗"), lineNum, err)
+			skipped++
+			continue
+		}
+
+		imported++
+	}
+
+	fmt.Printf("\n%s Imported %d rates", green("✓"), imported)
+	if skipped > 0 {
+		fmt.Printf(", %s %d rates", yellow("skipped"), skipped)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+type fxRateEntry struct {
+	Currency string  `json:"currency"`
+	Month    string  `json:"month"`
+	Rate     float64 `json:"rate"`
+}
+
+func runFXList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	rates, err := store.ListFXRates()
+	if err != nil {
+		return err
+	}
+
+	if fxJSON {
+		output := []fxRateEntry{}
+		for _, r := range rates {
+			output = append(output, fxRateEntry{Currency: r.Currency, Month: r.Month, Rate: r.Rate})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("Reporting currency: %s\n\n", cyan(store.ReportingCurrency()))
+
+	if len(rates) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No FX rates set.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Currency", "Month", "Rate (" + models.BaseCurrency + ")"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, r := range rates {
+		table.Rich([]string{
+			r.Currency,
+			r.Month,
+			strconv.FormatFloat(r.Rate, 'f', -1, 64),
+		}, []tablewriter.Colors{
+			{tablewriter.FgMagentaColor},
+			{},
+			{tablewriter.FgGreenColor},
+		})
+	}
+
+	table.Render()
+
+	return nil
+}
+
+func runFXCurrency(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	if len(args) == 0 {
+		fmt.Println(store.ReportingCurrency())
+		return nil
+	}
+
+	currency, err := parseCurrency(store, args[0])
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	config.Currency = currency
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	store.SetReportingCurrency(currency)
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Reporting currency set to %s\n", green("✓"), currency)
+
+	return nil
+}
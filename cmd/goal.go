@@ -18,17 +18,27 @@ import (
 
 // Config represents the config file structure
 type Config struct {
-	Goal *GoalConfig `json:"goal,omitempty"`
+	Goal     *GoalConfig `json:"goal,omitempty"`
+	Currency string      `json:"currency,omitempty"` // Reporting currency, defaults to USD
+
+	ImportProfiles map[string]*ImportProfile `json:"import_profiles,omitempty"`
+	Stripe         *StripeConfig             `json:"stripe,omitempty"`
+	Paddle         *PaddleConfig             `json:"paddle,omitempty"`
+	Gumroad        *GumroadConfig            `json:"gumroad,omitempty"`
+	Privacy        *PrivacyConfig            `json:"privacy,omitempty"`
 }
 
 // GoalConfig represents a MRR goal
 type GoalConfig struct {
-	Amount   int64  `json:"amount"`   // Amount in cents
+	Amount   int64  `json:"amount"`   // Amount in cents, in the reporting currency
 	Deadline string `json:"deadline"` // YYYY-MM format
 	SetAt    string `json:"set_at"`   // YYYY-MM-DD format
 }
 
-var goalDeadline string
+var (
+	goalDeadline string
+	goalJSON     bool
+)
 
 var goalCmd = &cobra.Command{
 	Use:   "goal",
@@ -39,6 +49,7 @@ Examples:
   mrr goal set 10000                  # Set $10,000 MRR goal
   mrr goal set 10000 --by 2026-06     # Set goal with deadline
   mrr goal status                     # Show progress towards goal
+  mrr goal status --json              # Progress as JSON
   mrr goal clear                      # Remove the goal`,
 }
 
@@ -68,6 +79,7 @@ var goalClearCmd = &cobra.Command{
 
 func init() {
 	goalSetCmd.Flags().StringVar(&goalDeadline, "by", "", "Target deadline (YYYY-MM)")
+	goalStatusCmd.Flags().BoolVarP(&goalJSON, "json", "j", false, "Output as JSON")
 
 	goalCmd.AddCommand(goalSetCmd)
 	goalCmd.AddCommand(goalStatusCmd)
@@ -75,11 +87,11 @@ func init() {
 }
 
 func getConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	dir, err := workspaceDir(currentWorkspace())
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".mrr-cli", "config.json"), nil
+	return filepath.Join(dir, "config.json"), nil
 }
 
 func loadConfig() (*Config, error) {
@@ -122,7 +134,8 @@ func saveConfig(config *Config) error {
 		return fmt.Errorf("failed to encode config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// The config can hold API keys and webhook secrets
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -130,6 +143,8 @@ func saveConfig(config *Config) error {
 }
 
 func runGoalSet(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	// Parse amount
 	var amount float64
 	if _, err := fmt.Sscanf(args[0], "%f", &amount); err != nil {
@@ -165,42 +180,127 @@ func runGoalSet(cmd *cobra.Command, args []string) error {
 	}
 
 	green := color.New(color.FgGreen).SprintFunc()
-	fmt.Printf("\n  %s Goal set: %s MRR", green("✓"), models.FormatAmount(amountCents, "USD"))
+	fmt.Printf("\n  %s Goal set: %s MRR", green("✓"), models.FormatAmount(amountCents, store.ReportingCurrency()))
 	if goalDeadline != "" {
 		deadline, _ := time.Parse("2006-01", goalDeadline)
 		fmt.Printf(" by %s", deadline.Format("January 2006"))
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 
 	return nil
 }
 
+// goalStatusData is the progress towards the goal, as output by
+// 'mrr goal status --json' and the API
+type goalStatusData struct {
+	Currency      string   `json:"currency"`
+	Goal          float64  `json:"goal"`
+	Deadline      string   `json:"deadline,omitempty"`
+	CurrentMRR    float64  `json:"current_mrr"`
+	Progress      float64  `json:"progress"`
+	Remaining     float64  `json:"remaining"`
+	Reached       bool     `json:"reached"`
+	DaysLeft      *int     `json:"days_left,omitempty"`
+	GrowthRate    *float64 `json:"growth_rate,omitempty"`
+	MonthsToGoal  *float64 `json:"months_to_goal,omitempty"`
+	ProjectedDate string   `json:"projected_date,omitempty"` // YYYY-MM
+	OnTrack       *bool    `json:"on_track,omitempty"`       // Projected to reach the goal by the deadline
+}
+
+// buildGoalStatus measures progress towards goal from s
+func buildGoalStatus(s db.Store, goal *GoalConfig) (goalStatusData, error) {
+	currentMonth := time.Now().Format("2006-01")
+	report, err := db.GetMonthlyReport(s, currentMonth)
+	if err != nil {
+		return goalStatusData{}, err
+	}
+
+	currentMRR := report.RecurringRevenue
+	progress := float64(currentMRR) / float64(goal.Amount) * 100
+	if progress > 100 {
+		progress = 100
+	}
+	remaining := goal.Amount - currentMRR
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	data := goalStatusData{
+		Currency:   report.Currency,
+		Goal:       float64(goal.Amount) / 100.0,
+		Deadline:   goal.Deadline,
+		CurrentMRR: float64(currentMRR) / 100.0,
+		Progress:   progress,
+		Remaining:  float64(remaining) / 100.0,
+		Reached:    currentMRR >= goal.Amount,
+	}
+
+	if goal.Deadline != "" {
+		deadline, _ := time.Parse("2006-01", goal.Deadline)
+		// Set to end of month
+		deadline = deadline.AddDate(0, 1, -1)
+		daysLeft := int(deadline.Sub(time.Now()).Hours() / 24)
+		data.DaysLeft = &daysLeft
+	}
+
+	// Growth projection
+	prevMRR, err := db.GetPreviousMonthMRR(s, currentMonth)
+	if err == nil && prevMRR > 0 && currentMRR > 0 {
+		growthRate := float64(currentMRR-prevMRR) / float64(prevMRR)
+		monthlyGrowthPercent := growthRate * 100
+		data.GrowthRate = &monthlyGrowthPercent
+
+		if growthRate > 0 && currentMRR < goal.Amount {
+			// months = log(goal/current) / log(1+growthRate)
+			monthsToGoal := math.Log(float64(goal.Amount)/float64(currentMRR)) / math.Log(1+growthRate)
+
+			if monthsToGoal > 0 && monthsToGoal < 120 {
+				projectedDate := time.Now().AddDate(0, int(math.Ceil(monthsToGoal)), 0)
+				data.MonthsToGoal = &monthsToGoal
+				data.ProjectedDate = projectedDate.Format("2006-01")
+
+				if goal.Deadline != "" {
+					deadline, _ := time.Parse("2006-01", goal.Deadline)
+					onTrack := projectedDate.Before(deadline) || data.ProjectedDate == goal.Deadline
+					data.OnTrack = &onTrack
+				}
+			}
+		}
+	}
+
+	return data, nil
+}
+
 func runGoalStatus(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	config, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
 	if config.Goal == nil {
+		if goalJSON {
+			return fmt.Errorf("no goal set")
+		}
 		yellow := color.New(color.FgYellow).SprintFunc()
 		fmt.Printf("\n  %s No goal set. Use 'mrr goal set <amount>' to set one.\n\n", yellow("⚠"))
 		return nil
 	}
 
-	// Get current MRR
-	currentMonth := time.Now().Format("2006-01")
-	report, err := db.GetMonthlyReport(currentMonth)
+	data, err := buildGoalStatus(store, config.Goal)
 	if err != nil {
 		return err
 	}
 
-	currentMRR := report.RecurringRevenue
-	goalAmount := config.Goal.Amount
-	progress := float64(currentMRR) / float64(goalAmount) * 100
-	if progress > 100 {
-		progress = 100
+	if goalJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
 	}
 
+	cents := func(amount float64) int64 { return int64(math.Round(amount * 100)) }
+
 	// Colors
 	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
 	green := color.New(color.FgGreen).SprintFunc()
@@ -209,9 +309,9 @@ func runGoalStatus(cmd *cobra.Command, args []string) error {
 
 	// Header
 	fmt.Println()
-	goalStr := fmt.Sprintf("🎯 Goal: %s MRR", models.FormatAmount(goalAmount, "USD"))
-	if config.Goal.Deadline != "" {
-		deadline, _ := time.Parse("2006-01", config.Goal.Deadline)
+	goalStr := fmt.Sprintf("🎯 Goal: %s MRR", models.FormatAmount(cents(data.Goal), data.Currency))
+	if data.Deadline != "" {
+		deadline, _ := time.Parse("2006-01", data.Deadline)
 		goalStr += fmt.Sprintf(" by %s", deadline.Format("January 2006"))
 	}
 	fmt.Printf("  %s\n", cyan(goalStr))
@@ -219,11 +319,11 @@ func runGoalStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Current progress
-	fmt.Printf("  %s  %s (%.1f%%)\n", bold("Current:"), green(models.FormatAmount(currentMRR, "USD")), progress)
+	fmt.Printf("  %s  %s (%.1f%%)\n", bold("Current:"), green(models.FormatAmount(cents(data.CurrentMRR), data.Currency)), data.Progress)
 
 	// Progress bar (32 chars)
 	barWidth := 32
-	filled := int(progress / 100 * float64(barWidth))
+	filled := int(data.Progress / 100 * float64(barWidth))
 	if filled > barWidth {
 		filled = barWidth
 	}
@@ -232,20 +332,12 @@ func runGoalStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Stats
-	remaining := goalAmount - currentMRR
-	if remaining < 0 {
-		remaining = 0
-	}
-	fmt.Printf("  %s %s / %s\n", bold("Progress:"), models.FormatAmount(currentMRR, "USD"), models.FormatAmount(goalAmount, "USD"))
-	fmt.Printf("  %s %s\n", bold("Remaining:"), models.FormatAmount(remaining, "USD"))
+	fmt.Printf("  %s %s / %s\n", bold("Progress:"), models.FormatAmount(cents(data.CurrentMRR), data.Currency), models.FormatAmount(cents(data.Goal), data.Currency))
+	fmt.Printf("  %s %s\n", bold("Remaining:"), models.FormatAmount(cents(data.Remaining), data.Currency))
 
 	// Time left if deadline set
-	if config.Goal.Deadline != "" {
-		deadline, _ := time.Parse("2006-01", config.Goal.Deadline)
-		// Set to end of month
-		deadline = deadline.AddDate(0, 1, -1)
-		now := time.Now()
-		daysLeft := int(deadline.Sub(now).Hours() / 24)
+	if data.DaysLeft != nil {
+		daysLeft := *data.DaysLeft
 		monthsLeft := int(math.Ceil(float64(daysLeft) / 30.0))
 
 		if monthsLeft > 0 {
@@ -258,39 +350,29 @@ func runGoalStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Growth projection
-	prevMRR, err := db.GetPreviousMonthMRR(currentMonth)
-	if err == nil && prevMRR > 0 && currentMRR > 0 {
-		growthRate := float64(currentMRR-prevMRR) / float64(prevMRR)
-		monthlyGrowthPercent := growthRate * 100
-
+	if data.GrowthRate != nil {
 		fmt.Println()
-		fmt.Printf("  %s At current growth rate (%.1f%%/mo):\n", "📈", monthlyGrowthPercent)
+		fmt.Printf("  %s At current growth rate (%.1f%%/mo):\n", "📈", *data.GrowthRate)
 
-		if growthRate > 0 && currentMRR < goalAmount {
-			// months = log(goal/current) / log(1+growthRate)
-			monthsToGoal := math.Log(float64(goalAmount)/float64(currentMRR)) / math.Log(1+growthRate)
+		if data.MonthsToGoal != nil {
+			fmt.Printf("     Projected to reach goal in: %.1f months", *data.MonthsToGoal)
 
-			if monthsToGoal > 0 && monthsToGoal < 120 {
-				projectedDate := time.Now().AddDate(0, int(math.Ceil(monthsToGoal)), 0)
-				fmt.Printf("     Projected to reach goal in: %.1f months", monthsToGoal)
-
-				// Check if within deadline
-				if config.Goal.Deadline != "" {
-					deadline, _ := time.Parse("2006-01", config.Goal.Deadline)
-					if projectedDate.Before(deadline) || projectedDate.Format("2006-01") == config.Goal.Deadline {
-						fmt.Printf(" %s\n", green("✅"))
-					} else {
-						fmt.Printf(" %s\n", yellow("⚠️"))
-					}
+			// Check if within deadline
+			if data.OnTrack != nil {
+				if *data.OnTrack {
+					fmt.Printf(" %s\n", green("✅"))
 				} else {
-					fmt.Println()
+					fmt.Printf(" %s\n", yellow("⚠️"))
 				}
-
-				fmt.Printf("     Expected date: %s\n", yellow(projectedDate.Format("January 2006")))
+			} else {
+				fmt.Println()
 			}
-		} else if currentMRR >= goalAmount {
+
+			projectedDate, _ := time.Parse("2006-01", data.ProjectedDate)
+			fmt.Printf("     Expected date: %s\n", yellow(projectedDate.Format("January 2006")))
+		} else if data.Reached {
 			fmt.Printf("     %s Goal reached! 🎉\n", green("✓"))
-		} else if growthRate <= 0 {
+		} else if *data.GrowthRate <= 0 {
 			fmt.Printf("     %s Negative growth - goal may not be reachable at current pace\n", yellow("⚠"))
 		}
 	}
@@ -1,136 +1,797 @@
 package cmd
 
 import (
-	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/importer"
 	"github.com/indiekitai/mrr-cli/models"
 )
 
 var importCmd = &cobra.Command{
-	Use:   "import <file.csv>",
-	Short: "Import entries from CSV",
-	Long: `Import revenue entries from a CSV file.
+	Use:   "import <file>",
+	Short: "Import entries from CSV or JSON",
+	Long: `Import revenue entries from a CSV or JSON file, or from stdin with "-".
 
 CSV format:
-  date,amount,source,type,note
-  2026-02-01,49.99,stripe,recurring,SaaS subscription
-  2026-02-15,19.00,gumroad,one-time,ebook sale
+  date,amount,source,type,note,customer,currency
+  2026-02-01,49.99,stripe,recurring,SaaS subscription,acme,USD
+  2026-02-15,19.00,gumroad,one-time,ebook sale,,EUR
+
+Columns are matched by header name and may come in any order. The note,
+customer and currency columns are optional; rows without them use --customer
+and --currency. Files without a source or type column need --source and --type.
+
+Other layouts can be mapped onto these columns with --map, and read with
+--date-format, --decimal and --thousands. Amounts in parentheses are
+negative. Save the options with --save-profile and reuse them with --profile.
+
+Provider exports can be imported directly with --format:
+  stripe-payments       Charges; failed and refunded ones and those naming
+                        a subscription are skipped, the rest are one-time
+  stripe-invoices       Paid one-off invoices; subscription invoices are
+                        skipped, stripe-subscriptions carries their MRR
+  stripe-subscriptions  Subscriptions; trials are skipped, cancelled ones
+                        keep their end date
+  gumroad               Sales; memberships (with a recurrence) are recurring
+  paddle-transactions   Completed one-off transactions; subscription ones are
+                        skipped, paddle-subscriptions carries their MRR
+  paddle-subscriptions  Subscriptions; trialing and paused ones are skipped
+  lemonsqueezy          Orders; subscription orders are recurring
+
+The output of 'mrr export --json' is imported with --format json, or one
+entry object per line with --format jsonl. Customers missing from the
+database are added, so an export round-trips to another machine exactly.
+
+Customers in provider exports are linked to existing customers by name.
+
+Imports are idempotent: each row is stored with the provider's ID (or a hash
+of its content), so importing the same file again skips unchanged rows and
+updates changed ones, such as a later refund or cancellation.
+
+The import runs in a single transaction. Invalid rows are reported and left
+out; with --strict any invalid row aborts the import and nothing is written.
+--dry-run validates the file and shows what would be inserted or updated and
+how each month's MRR would change, without writing anything.
 
 Examples:
-  mrr import entries.csv`,
+  mrr import entries.csv
+  mrr import entries.csv --customer acme
+  mrr import unified_payments.csv --format stripe-payments
+  mrr import subscriptions.csv --format stripe-subscriptions
+  mrr import sales.csv --format gumroad
+  mrr import bank.csv --map "date=Paid On,amount=Total" --date-format DD.MM.YYYY \
+    --decimal , --source manual --type one-time --save-profile bank
+  mrr import bank-march.csv --profile bank
+  mrr export --json | ssh other-host mrr import - --format json
+  mrr import entries.jsonl --format jsonl
+  mrr import entries.csv --dry-run
+  mrr import entries.csv --strict --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImport,
 }
 
+var (
+	importCustomer    string
+	importCurrency    string
+	importFormat      string
+	importDryRun      bool
+	importStrict      bool
+	importJSON        bool
+	importMapping     map[string]string
+	importDateFormats []string
+	importDecimal     string
+	importThousands   string
+	importSource      string
+	importType        string
+	importProfile     string
+	importSaveProfile string
+)
+
+// ImportProfile is a saved CSV layout, applied with 'mrr import --profile'
+type ImportProfile struct {
+	Format      string            `json:"format,omitempty"`
+	Mapping     map[string]string `json:"mapping,omitempty"`
+	DateFormats []string          `json:"date_formats,omitempty"`
+	Decimal     string            `json:"decimal,omitempty"`
+	Thousands   string            `json:"thousands,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Type        string            `json:"type,omitempty"`
+}
+
+// errRollback discards the transaction of a dry run or an aborted strict import
+var errRollback = errors.New("import rolled back")
+
+func init() {
+	importCmd.Flags().StringVarP(&importCustomer, "customer", "c", "", "Customer name or ID for rows without a customer column")
+	importCmd.Flags().StringVar(&importCurrency, "currency", "", "ISO currency code for rows without a currency column (defaults to reporting currency)")
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "mrr", fmt.Sprintf("Input format (%s)", strings.Join(importer.Formats, ", ")))
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate and show what would be imported without writing anything")
+	importCmd.Flags().BoolVar(&importStrict, "strict", false, "Import nothing if any row is invalid")
+	importCmd.Flags().BoolVarP(&importJSON, "json", "j", false, "Output the result as JSON")
+	importCmd.Flags().StringToStringVar(&importMapping, "map", nil, "Map expected columns to the file's headers (e.g. date=Paid On,amount=Total)")
+	importCmd.Flags().StringSliceVar(&importDateFormats, "date-format", nil, "Date formats to try first (e.g. DD/MM/YYYY or a Go layout)")
+	importCmd.Flags().StringVar(&importDecimal, "decimal", "", "Decimal separator (default \".\")")
+	importCmd.Flags().StringVar(&importThousands, "thousands", "", "Thousands separator (default: any of , . space ')")
+	importCmd.Flags().StringVar(&importSource, "source", "", "Source for rows without a source column")
+	importCmd.Flags().StringVar(&importType, "type", "", "Type for rows without a type column (recurring, one-time)")
+	importCmd.Flags().StringVar(&importProfile, "profile", "", "Use a saved import profile")
+	importCmd.Flags().StringVar(&importSaveProfile, "save-profile", "", "Save this import's layout options as a named profile")
+}
+
+type importRow struct {
+	Line     int     `json:"line"`
+	Kind     string  `json:"kind"`   // entry or subscription
+	Action   string  `json:"action"` // inserted, updated or duplicate
+	Date     string  `json:"date"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Source   string  `json:"source"`
+	Type     string  `json:"type,omitempty"`
+	Interval string  `json:"interval,omitempty"`
+	Customer string  `json:"customer,omitempty"`
+	Note     string  `json:"note,omitempty"`
+}
+
+type importIssue struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+type importImpact struct {
+	Month    string  `json:"month"`
+	Currency string  `json:"currency"`
+	Before   float64 `json:"before"`
+	After    float64 `json:"after"`
+	Change   float64 `json:"change"`
+}
+
+type importOutput struct {
+	File           string         `json:"file"`
+	Format         string         `json:"format"`
+	DryRun         bool           `json:"dry_run"`
+	Strict         bool           `json:"strict"`
+	Committed      bool           `json:"committed"`
+	Inserted       int            `json:"inserted"`
+	Updated        int            `json:"updated"`
+	Duplicates     int            `json:"duplicates"`
+	CustomersAdded int            `json:"customers_added,omitempty"`
+	Removed        int            `json:"removed,omitempty"` // Entries stored for superseded rows
+	Rows           []importRow    `json:"rows"`
+	Skipped        []importIssue  `json:"skipped"`
+	Rejected       []importIssue  `json:"rejected"`
+	MRRImpact      []importImpact `json:"mrr_impact,omitempty"`
+}
+
+// add records a stored row under its outcome
+func (o *importOutput) add(row importRow, outcome db.ImportOutcome) {
+	switch outcome {
+	case db.ImportInserted:
+		row.Action = "inserted"
+		o.Inserted++
+	case db.ImportUpdated:
+		row.Action = "updated"
+		o.Updated++
+	default:
+		row.Action = "duplicate"
+		o.Duplicates++
+	}
+	o.Rows = append(o.Rows, row)
+}
+
+func (o *importOutput) reject(line int, err error) {
+	o.Rejected = append(o.Rejected, importIssue{Line: line, Reason: err.Error()})
+}
+
 func runImport(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	filePath := args[0]
 
-	file, err := os.Open(filePath)
+	file := os.Stdin
+	if filePath != "-" {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		file = f
+	}
+
+	profile, err := importProfileFromFlags(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return err
 	}
-	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	result, err := importer.Parse(profile.Format, file, importer.Options{
+		Mapping:     profile.Mapping,
+		DateLayouts: profile.DateFormats,
+		Decimal:     profile.Decimal,
+		Thousands:   profile.Thousands,
+		Source:      profile.Source,
+		Type:        profile.Type,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to read CSV: %w", err)
+		return err
 	}
 
-	if len(records) < 2 {
-		return fmt.Errorf("CSV file is empty or has only headers")
+	if importSaveProfile != "" {
+		if err := saveImportProfile(importSaveProfile, profile); err != nil {
+			return err
+		}
+		if !importJSON {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s Saved import profile %s\n", green("✓"), importSaveProfile)
+		}
 	}
 
-	// Validate header
-	header := records[0]
-	expectedHeader := []string{"date", "amount", "source", "type", "note"}
-	if len(header) < 4 {
-		return fmt.Errorf("invalid CSV header, expected: %v", expectedHeader)
+	defaultCustomerID, err := resolveCustomer(store, importCustomer)
+	if err != nil {
+		return err
 	}
 
-	green := color.New(color.FgGreen).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-	red := color.New(color.FgRed).SprintFunc()
+	var defaultCustomerName string
+	if defaultCustomerID != 0 {
+		customer, err := store.GetCustomer(defaultCustomerID)
+		if err != nil {
+			return err
+		}
+		defaultCustomerName = customer.Name
+	}
 
-	imported := 0
-	skipped := 0
+	defaultCurrency, err := parseCurrency(store, importCurrency)
+	if err != nil {
+		return err
+	}
 
-	for i, record := range records[1:] {
-		lineNum := i + 2 // Account for header and 0-indexing
+	output := newImportOutput(filePath, profile.Format)
+	output.DryRun, output.Strict = importDryRun, importStrict
+	err = storeImport(store, result, importSettings{
+		customerID:   defaultCustomerID,
+		customerName: defaultCustomerName,
+		currency:     defaultCurrency,
+		// The native format names customers that must exist; exports carry
+		// customers by name and missing ones are added
+		strictCustomers: profile.Format == "mrr",
+		createCustomers: profile.Format == "json" || profile.Format == "jsonl",
+	}, &output)
+	if err != nil {
+		return err
+	}
 
-		if len(record) < 4 {
-			fmt.Printf("%s Line %d: insufficient fields, skipping\n", yellow("⚠"), lineNum)
-			skipped++
-			continue
+	return finishImport(&output, importJSON)
+}
+
+// newImportOutput starts the result of importing from source
+func newImportOutput(source, format string) importOutput {
+	return importOutput{
+		File:     source,
+		Format:   format,
+		Rows:     []importRow{},
+		Skipped:  []importIssue{},
+		Rejected: []importIssue{},
+	}
+}
+
+// importSettings says how parsed rows are stored
+type importSettings struct {
+	customerID      int64  // For entries without a customer
+	customerName    string // customerID's name
+	currency        string // For rows without a currency
+	strictCustomers bool   // Reject entries naming unknown customers
+	createCustomers bool   // Add unknown customers instead of leaving entries unlinked
+	origin          string // Origin of new rows, import if empty
+
+	// after runs inside the import's transaction once the rows are stored,
+	// unless it is rolled back
+	after func(tx db.Store) error
+}
+
+// storeImport stores parsed rows in s in a single transaction, recording
+// every outcome in output. Dry runs and strict imports with rejected rows
+// are rolled back.
+func storeImport(s db.Store, result *importer.Result, settings importSettings, output *importOutput) error {
+	for _, skip := range result.Skipped {
+		output.Skipped = append(output.Skipped, importIssue{Line: skip.Line, Reason: skip.Reason})
+	}
+	for _, reject := range result.Rejected {
+		output.Rejected = append(output.Rejected, importIssue{Line: reject.Line, Reason: reject.Reason})
+	}
+
+	entries := make([]models.Entry, 0, len(result.Entries))
+	entryLines := make([]int, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		entry := models.Entry{
+			Amount:       e.Amount,
+			Currency:     e.Currency,
+			Source:       e.Source,
+			Type:         e.Type,
+			Note:         e.Note,
+			Date:         e.Date,
+			CustomerID:   settings.customerID,
+			CustomerName: settings.customerName,
+			ImportKey:    e.Key,
+			Origin:       settings.origin,
+		}
+		if entry.Currency == "" {
+			entry.Currency = settings.currency
 		}
 
-		// Parse date
-		dateStr := strings.TrimSpace(record[0])
-		date, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			fmt.Printf("%s Line %d: invalid date '%s', skipping\n", red("✗"), lineNum, dateStr)
-			skipped++
-			continue
+		if e.Customer != "" {
+			if settings.createCustomers {
+				entry.CustomerID = 0
+				entry.CustomerName = e.Customer
+			} else if settings.strictCustomers {
+				id, err := resolveCustomer(s, e.Customer)
+				if err != nil {
+					output.reject(e.Line, err)
+					continue
+				}
+				entry.CustomerID = id
+				entry.CustomerName = e.Customer
+			} else if customer, err := s.GetCustomerByName(e.Customer); err == nil {
+				entry.CustomerID = customer.ID
+				entry.CustomerName = customer.Name
+			}
 		}
 
-		// Parse amount
-		amountStr := strings.TrimSpace(record[1])
-		amountStr = strings.TrimPrefix(amountStr, "$")
-		amountFloat, err := strconv.ParseFloat(amountStr, 64)
-		if err != nil {
-			fmt.Printf("%s Line %d: invalid amount '%s', skipping\n", red("✗"), lineNum, record[1])
-			skipped++
-			continue
+		entries = append(entries, entry)
+		entryLines = append(entryLines, e.Line)
+	}
+
+	if output.Strict && len(output.Rejected) > 0 {
+		return nil
+	}
+
+	err := s.Transaction(func(tx db.Store) error {
+		months := importMonths(result)
+
+		var before map[string]int64
+		if output.DryRun {
+			var err error
+			if before, err = monthMRRs(tx, months); err != nil {
+				return err
+			}
 		}
-		amountCents := int64(amountFloat * 100)
 
-		// Validate source
-		source := strings.TrimSpace(strings.ToLower(record[2]))
-		if !models.IsValidSource(source) {
-			fmt.Printf("%s Line %d: invalid source '%s', skipping\n", red("✗"), lineNum, source)
-			skipped++
-			continue
+		for _, key := range result.Superseded {
+			n, err := tx.DeleteImportedEntries(key)
+			if err != nil {
+				return err
+			}
+			output.Removed += n
 		}
 
-		// Validate type
-		entryType := strings.TrimSpace(strings.ToLower(record[3]))
-		if !models.IsValidType(entryType) {
-			fmt.Printf("%s Line %d: invalid type '%s', skipping\n", red("✗"), lineNum, entryType)
-			skipped++
-			continue
+		for i := range entries {
+			e := &entries[i]
+			if settings.createCustomers && e.CustomerName != "" {
+				id, err := importCustomerID(tx, e.CustomerName, output)
+				if err != nil {
+					output.reject(entryLines[i], err)
+					continue
+				}
+				e.CustomerID = id
+			}
+
+			outcome, err := tx.ImportEntry(e)
+			if err != nil {
+				output.reject(entryLines[i], err)
+				continue
+			}
+			output.add(importRow{
+				Line:     entryLines[i],
+				Kind:     "entry",
+				Date:     e.Date.Format("2006-01-02"),
+				Amount:   float64(e.Amount) / 100.0,
+				Currency: e.Currency,
+				Source:   e.Source,
+				Type:     e.Type,
+				Customer: e.CustomerName,
+				Note:     e.Note,
+			}, outcome)
+		}
+
+		for _, parsed := range result.Subscriptions {
+			sub := models.Subscription{
+				Customer:   parsed.Customer,
+				Amount:     parsed.Amount,
+				Currency:   parsed.Currency,
+				Interval:   parsed.Interval,
+				Source:     parsed.Source,
+				Note:       parsed.Note,
+				StartDate:  parsed.StartDate,
+				CancelDate: parsed.CancelDate,
+				ImportKey:  parsed.Key,
+				Origin:     settings.origin,
+			}
+			if sub.Currency == "" {
+				sub.Currency = settings.currency
+			}
+
+			outcome, err := tx.ImportSubscription(&sub)
+			if err != nil {
+				output.reject(parsed.Line, err)
+				continue
+			}
+			output.add(importRow{
+				Line:     parsed.Line,
+				Kind:     "subscription",
+				Date:     sub.StartDate.Format("2006-01-02"),
+				Amount:   float64(sub.Amount) / 100.0,
+				Currency: sub.Currency,
+				Source:   sub.Source,
+				Interval: sub.Interval,
+				Customer: sub.Customer,
+				Note:     sub.Note,
+			}, outcome)
 		}
 
-		// Note (optional)
-		note := ""
-		if len(record) > 4 {
-			note = strings.TrimSpace(record[4])
+		if output.Strict && len(output.Rejected) > 0 {
+			return errRollback
 		}
 
-		// Add to database
-		_, err = db.AddEntry(amountCents, source, entryType, note, date)
+		if output.DryRun {
+			after, err := monthMRRs(tx, months)
+			if err != nil {
+				return err
+			}
+			currency := tx.ReportingCurrency()
+			for _, month := range months {
+				if after[month] == before[month] {
+					continue
+				}
+				output.MRRImpact = append(output.MRRImpact, importImpact{
+					Month:    month,
+					Currency: currency,
+					Before:   float64(before[month]) / 100.0,
+					After:    float64(after[month]) / 100.0,
+					Change:   float64(after[month]-before[month]) / 100.0,
+				})
+			}
+			return errRollback
+		}
+
+		if settings.after != nil {
+			return settings.after(tx)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return err
+	}
+	output.Committed = err == nil
+
+	return nil
+}
+
+// importCustomerID returns the ID of the named customer, adding the customer
+// if it does not exist
+func importCustomerID(s db.Store, name string, output *importOutput) (int64, error) {
+	if customer, err := s.GetCustomerByName(name); err == nil {
+		return customer.ID, nil
+	}
+	id, err := s.AddCustomer(name, "", "")
+	if err != nil {
+		return 0, err
+	}
+	output.CustomersAdded++
+	return id, nil
+}
+
+// importProfileFromFlags returns the import's layout options: the --profile
+// if given, overridden by any layout flags set explicitly
+func importProfileFromFlags(cmd *cobra.Command) (*ImportProfile, error) {
+	profile := &ImportProfile{}
+	if importProfile != "" {
+		config, err := loadConfig()
 		if err != nil {
-			fmt.Printf("%s Line %d: failed to add entry: %v\n", red("✗"), lineNum, err)
-			skipped++
-			continue
+			return nil, err
 		}
+		saved, ok := config.ImportProfiles[importProfile]
+		if !ok {
+			return nil, fmt.Errorf("import profile not found: %s (save one with --save-profile)", importProfile)
+		}
+		*profile = *saved
+	}
 
-		imported++
+	flags := cmd.Flags()
+	if flags.Changed("format") || profile.Format == "" {
+		profile.Format = importFormat
+	}
+	if flags.Changed("map") {
+		profile.Mapping = importMapping
+	}
+	if flags.Changed("date-format") {
+		profile.DateFormats = importDateFormats
+	}
+	if flags.Changed("decimal") {
+		profile.Decimal = importDecimal
+	}
+	if flags.Changed("thousands") {
+		profile.Thousands = importThousands
+	}
+	if flags.Changed("source") {
+		profile.Source = strings.ToLower(importSource)
+	}
+	if flags.Changed("type") {
+		profile.Type = strings.ToLower(importType)
 	}
 
-	fmt.Printf("\n%s Imported %d entries", green("✓"), imported)
-	if skipped > 0 {
-		fmt.Printf(", %s %d entries", yellow("skipped"), skipped)
+	if profile.Source != "" && !models.IsValidSource(profile.Source) {
+		return nil, fmt.Errorf("invalid source: %s (valid: %v)", profile.Source, models.ValidSources)
+	}
+	if profile.Type != "" && !models.IsValidType(profile.Type) {
+		return nil, fmt.Errorf("invalid type: %s (valid: %v)", profile.Type, models.ValidTypes)
+	}
+
+	return profile, nil
+}
+
+// saveImportProfile stores an import profile in the workspace config
+func saveImportProfile(name string, profile *ImportProfile) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if config.ImportProfiles == nil {
+		config.ImportProfiles = make(map[string]*ImportProfile)
+	}
+	config.ImportProfiles[name] = profile
+
+	return saveConfig(config)
+}
+
+// importMonths returns every month from the earliest one an import touches
+// through the later of its last month and the current month
+func importMonths(result *importer.Result) []string {
+	var first, last time.Time
+	extend := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	for _, e := range result.Entries {
+		extend(e.Date)
+	}
+	for _, s := range result.Subscriptions {
+		extend(s.StartDate)
+		if s.CancelDate != nil {
+			extend(*s.CancelDate)
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+	if now := time.Now(); now.After(last) {
+		last = now
+	}
+
+	var months []string
+	end := last.Format("2006-01")
+	for t := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); ; t = t.AddDate(0, 1, 0) {
+		month := t.Format("2006-01")
+		months = append(months, month)
+		if month == end {
+			break
+		}
+	}
+	return months
+}
+
+// monthMRRs returns the MRR of each month
+func monthMRRs(s db.Store, months []string) (map[string]int64, error) {
+	mrrs := make(map[string]int64, len(months))
+	for _, month := range months {
+		mrr, err := db.GetMonthMRR(s, month)
+		if err != nil {
+			return nil, err
+		}
+		mrrs[month] = mrr
+	}
+	return mrrs, nil
+}
+
+// finishImport prints the outcome of an import and fails if a strict import
+// was aborted
+func finishImport(output *importOutput, asJSON bool) error {
+	sort.SliceStable(output.Rows, func(i, j int) bool {
+		return output.Rows[i].Line < output.Rows[j].Line
+	})
+	sort.SliceStable(output.Rejected, func(i, j int) bool {
+		return output.Rejected[i].Line < output.Rejected[j].Line
+	})
+
+	aborted := output.Strict && len(output.Rejected) > 0
+	if aborted {
+		output.Rows = []importRow{}
+		output.Inserted, output.Updated, output.Duplicates, output.CustomersAdded, output.Removed = 0, 0, 0, 0, 0
 	}
-	fmt.Println()
 
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			return err
+		}
+	} else {
+		printImport(output)
+	}
+
+	if aborted {
+		return fmt.Errorf("import aborted: %d invalid rows, nothing was imported", len(output.Rejected))
+	}
 	return nil
 }
+
+func printImport(output *importOutput) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	for _, skip := range output.Skipped {
+		fmt.Printf("%s %s%s, skipping\n", yellow("⚠"), linePrefix(skip.Line), skip.Reason)
+	}
+	for _, reject := range output.Rejected {
+		fmt.Printf("%s %s%s\n", red("✗"), linePrefix(reject.Line), reject.Reason)
+	}
+	if len(output.Skipped)+len(output.Rejected) > 0 {
+		fmt.Println()
+	}
+
+	if output.Strict && len(output.Rejected) > 0 {
+		return
+	}
+
+	if output.DryRun {
+		printImportRows(output.Rows)
+		printImportImpact(output.MRRImpact)
+
+		fmt.Printf("%s Dry run: %d would be inserted, %d updated, %d duplicates",
+			cyan("ℹ"), output.Inserted, output.Updated, output.Duplicates)
+	} else {
+		fmt.Printf("%s %d inserted, %d updated, %d duplicates",
+			green("✓"), output.Inserted, output.Updated, output.Duplicates)
+	}
+	if n := len(output.Rejected); n > 0 {
+		fmt.Printf(", %s", red(fmt.Sprintf("%d rejected", n)))
+	}
+	if n := output.CustomersAdded; n > 0 {
+		fmt.Printf(", %d customers added", n)
+	}
+	if n := output.Removed; n > 0 {
+		fmt.Printf(", %d removed", n)
+	}
+	if n := len(output.Skipped); n > 0 {
+		fmt.Printf(", %s", yellow(fmt.Sprintf("%d skipped", n)))
+	}
+	fmt.Println()
+}
+
+// linePrefix names the input line an issue is on; rows that didn't come
+// from a file, such as synced objects, have no line
+func linePrefix(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Line %d: ", line)
+}
+
+// printImportRows lists the rows a dry run would insert or update
+func printImportRows(rows []importRow) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Line", "Action", "Date", "Amount", "Source", "Type", "Customer", "Note"})
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, 8)
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	count := 0
+	for _, r := range rows {
+		if r.Action == "duplicate" {
+			continue
+		}
+		count++
+
+		actionColor := tablewriter.FgGreenColor
+		if r.Action == "updated" {
+			actionColor = tablewriter.FgYellowColor
+		}
+		kind := r.Type
+		if r.Kind == "subscription" {
+			kind = r.Interval + "ly subscription"
+		}
+		note := r.Note
+		if len(note) > 30 {
+			note = note[:27] + "..."
+		}
+		line := ""
+		if r.Line > 0 {
+			line = fmt.Sprintf("%d", r.Line)
+		}
+
+		table.Rich([]string{
+			line,
+			r.Action,
+			r.Date,
+			models.FormatAmount(int64(math.Round(r.Amount*100)), r.Currency),
+			r.Source,
+			kind,
+			r.Customer,
+			note,
+		}, []tablewriter.Colors{
+			{},
+			{actionColor},
+			{},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgMagentaColor},
+			{},
+			{tablewriter.FgBlueColor},
+			{},
+		})
+	}
+
+	if count > 0 {
+		table.Render()
+		fmt.Println()
+	}
+}
+
+// printImportImpact shows how a dry run would change each month's MRR
+func printImportImpact(impact []importImpact) {
+	if len(impact) == 0 {
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Month", "MRR Before", "MRR After", "Change"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	cents := func(amount float64) int64 { return int64(math.Round(amount * 100)) }
+	for _, m := range impact {
+		changeColor := tablewriter.FgGreenColor
+		change := models.FormatAmount(cents(m.Change), m.Currency)
+		if m.Change < 0 {
+			changeColor = tablewriter.FgRedColor
+		} else {
+			change = "+" + change
+		}
+
+		table.Rich([]string{
+			m.Month,
+			models.FormatAmount(cents(m.Before), m.Currency),
+			models.FormatAmount(cents(m.After), m.Currency),
+			change,
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{tablewriter.Bold},
+			{changeColor},
+		})
+	}
+
+	table.Render()
+	fmt.Println()
+}
@@ -14,10 +14,12 @@ import (
 )
 
 var (
-	listMonth  string
-	listSource string
-	listType   string
-	listJSON   bool
+	listMonth    string
+	listSource   string
+	listType     string
+	listJSON     bool
+	listCustomer string
+	listOrigin   string
 )
 
 var listCmd = &cobra.Command{
@@ -30,6 +32,8 @@ Examples:
   mrr list --month 2024-01
   mrr list --source stripe
   mrr list --type recurring
+  mrr list --customer acme
+  mrr list --origin sync              # Only entries pulled by 'mrr sync'
   mrr list --json`,
 	RunE: runList,
 }
@@ -38,6 +42,8 @@ func init() {
 	listCmd.Flags().StringVarP(&listMonth, "month", "m", "", "Filter by month (YYYY-MM)")
 	listCmd.Flags().StringVarP(&listSource, "source", "s", "", "Filter by source")
 	listCmd.Flags().StringVarP(&listType, "type", "t", "", "Filter by type")
+	listCmd.Flags().StringVarP(&listCustomer, "customer", "c", "", "Filter by customer name or ID")
+	listCmd.Flags().StringVar(&listOrigin, "origin", "", "Filter by origin (manual, import, sync, webhook)")
 	listCmd.Flags().BoolVarP(&listJSON, "json", "j", false, "Output as JSON")
 }
 
@@ -45,26 +51,44 @@ type listEntry struct {
 	ID        int64   `json:"id"`
 	Date      string  `json:"date"`
 	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
 	Source    string  `json:"source"`
 	Type      string  `json:"type"`
 	Note      string  `json:"note,omitempty"`
+	Customer  string  `json:"customer,omitempty"`
+	Origin    string  `json:"origin"`
 	CreatedAt string  `json:"created_at"`
 }
 
 type listOutput struct {
-	Entries []listEntry `json:"entries"`
-	Total   float64     `json:"total"`
-	Count   int         `json:"count"`
+	Entries  []listEntry `json:"entries"`
+	Total    float64     `json:"total"`
+	Currency string      `json:"currency"`
+	Count    int         `json:"count"`
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	entries, err := db.ListEntries(listMonth, listSource, listType)
+	store := storeFrom(cmd)
+
+	customerID, err := resolveCustomer(store, listCustomer)
+	if err != nil {
+		return err
+	}
+
+	entries, err := store.ListEntries(listMonth, listSource, listType, customerID)
+	if err != nil {
+		return err
+	}
+
+	entries = filterOrigin(entries, listOrigin)
+
+	total, err := sumEntries(store, entries)
 	if err != nil {
 		return err
 	}
 
 	if listJSON {
-		return listAsJSON(entries)
+		return listAsJSON(entries, total, store.ReportingCurrency())
 	}
 
 	if len(entries) == 0 {
@@ -74,7 +98,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Date", "Amount", "Source", "Type", "Note"})
+	table.SetHeader([]string{"ID", "Date", "Amount", "Source", "Type", "Customer", "Note"})
 	table.SetBorder(false)
 	table.SetHeaderColor(
 		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
@@ -83,12 +107,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
 		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
 		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
 	)
 
-	var total int64
 	for _, e := range entries {
-		total += e.Amount
-		
 		note := e.Note
 		if len(note) > 30 {
 			note = note[:27] + "..."
@@ -102,9 +124,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		table.Rich([]string{
 			fmt.Sprintf("%d", e.ID),
 			e.Date.Format("2006-01-02"),
-			models.FormatAmount(e.Amount, "USD"),
+			models.FormatAmount(e.Amount, e.Currency),
 			e.Source,
 			e.Type,
+			e.CustomerName,
 			note,
 		}, []tablewriter.Colors{
 			{},
@@ -112,6 +135,7 @@ func runList(cmd *cobra.Command, args []string) error {
 			{tablewriter.FgGreenColor},
 			{tablewriter.FgMagentaColor},
 			{typeColor},
+			{tablewriter.FgBlueColor},
 			{},
 		})
 	}
@@ -120,32 +144,77 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	cyan := color.New(color.FgCyan).SprintFunc()
 	green := color.New(color.FgGreen).SprintFunc()
-	fmt.Printf("\n%s entries, total: %s\n", cyan(fmt.Sprintf("%d", len(entries))), green(models.FormatAmount(total, "USD")))
+	fmt.Printf("\n%s entries, total: %s\n", cyan(fmt.Sprintf("%d", len(entries))), green(models.FormatAmount(total, store.ReportingCurrency())))
 
 	return nil
 }
 
-func listAsJSON(entries []models.Entry) error {
+func listAsJSON(entries []models.Entry, total int64, currency string) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newListOutput(entries, total, currency))
+}
+
+// newListOutput is the JSON for entries totalling total in currency
+func newListOutput(entries []models.Entry, total int64, currency string) listOutput {
 	var output listOutput
-	var total int64
 
 	for _, e := range entries {
-		total += e.Amount
-		output.Entries = append(output.Entries, listEntry{
-			ID:        e.ID,
-			Date:      e.Date.Format("2006-01-02"),
-			Amount:    float64(e.Amount) / 100.0,
-			Source:    e.Source,
-			Type:      e.Type,
-			Note:      e.Note,
-			CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		})
+		output.Entries = append(output.Entries, newListEntry(e))
 	}
 
 	output.Total = float64(total) / 100.0
+	output.Currency = currency
 	output.Count = len(entries)
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return output
+}
+
+// newListEntry is the JSON for an entry
+func newListEntry(e models.Entry) listEntry {
+	return listEntry{
+		ID:        e.ID,
+		Date:      e.Date.Format("2006-01-02"),
+		Amount:    float64(e.Amount) / 100.0,
+		Currency:  e.Currency,
+		Source:    e.Source,
+		Type:      e.Type,
+		Note:      e.Note,
+		Customer:  e.CustomerName,
+		Origin:    e.Origin,
+		CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// filterOrigin keeps the entries recorded with origin, or all of them if
+// origin is empty
+func filterOrigin(entries []models.Entry, origin string) []models.Entry {
+	if origin == "" {
+		return entries
+	}
+	matching := entries[:0]
+	for _, e := range entries {
+		if e.Origin == origin {
+			matching = append(matching, e)
+		}
+	}
+	return matching
+}
+
+// sumEntries totals entries in s's reporting currency
+func sumEntries(s db.Store, entries []models.Entry) (int64, error) {
+	fx, err := db.LoadFXTable(s)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		amount, err := fx.Convert(e.Amount, e.Currency, s.ReportingCurrency(), e.Date.Format("2006-01"))
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return total, nil
 }
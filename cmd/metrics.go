@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+// trailingPeriods are the trailing windows, in months, retention is
+// reported over
+var trailingPeriods = []int{3, 6, 12}
+
+var (
+	metricsMonth  string
+	metricsMonths int
+	metricsJSON   bool
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show churn and retention metrics",
+	Long: `Show churn and revenue retention per month and over the trailing 3, 6
+and 12 months, computed from subscription history.
+
+Each period follows the customers paying at its start:
+  Logo churn   Share of those customers no longer paying at the end
+  MRR churn    Share of their MRR lost to cancellations and downgrades
+  GRR          Gross revenue retention: their MRR kept, without upgrades
+  NRR          Net revenue retention: their MRR at the end over the start,
+               with upgrades; above 100% means expansion outgrew churn
+
+Customers who joined during a period and manual recurring entries don't
+count towards it.
+
+Examples:
+  mrr metrics                       # Last 6 months and trailing periods
+  mrr metrics --month 2026-06       # Periods ending in June 2026
+  mrr metrics --months 12
+  mrr metrics --json`,
+	Args: cobra.NoArgs,
+	RunE: runMetrics,
+}
+
+func init() {
+	metricsCmd.Flags().StringVarP(&metricsMonth, "month", "m", "", "Last month (YYYY-MM, defaults to current)")
+	metricsCmd.Flags().IntVar(&metricsMonths, "months", 6, "Number of months to show")
+	metricsCmd.Flags().BoolVarP(&metricsJSON, "json", "j", false, "Output as JSON")
+}
+
+// retentionRates are the churn and retention rates of a period in percent,
+// nil when no customers were paying at its start
+type retentionRates struct {
+	LogoChurnRate     *float64 `json:"logo_churn_rate,omitempty"`
+	GrossMRRChurnRate *float64 `json:"gross_mrr_churn_rate,omitempty"`
+	GRR               *float64 `json:"grr,omitempty"`
+	NRR               *float64 `json:"nrr,omitempty"`
+}
+
+func newRetentionRates(r *db.Retention) retentionRates {
+	if r.StartCustomers == 0 || r.StartMRR == 0 {
+		return retentionRates{}
+	}
+	logoChurn := r.LogoChurnRate() * 100
+	grossChurn := r.GrossMRRChurnRate() * 100
+	grr := r.GrossRevenueRetention() * 100
+	nrr := r.NetRevenueRetention() * 100
+	return retentionRates{
+		LogoChurnRate:     &logoChurn,
+		GrossMRRChurnRate: &grossChurn,
+		GRR:               &grr,
+		NRR:               &nrr,
+	}
+}
+
+type retentionData struct {
+	From             string  `json:"from"`
+	To               string  `json:"to"`
+	Months           int     `json:"months"`
+	StartCustomers   int     `json:"start_customers"`
+	ChurnedCustomers int     `json:"churned_customers"`
+	StartMRR         float64 `json:"start_mrr"`
+	ChurnedMRR       float64 `json:"churned_mrr"`
+	ContractionMRR   float64 `json:"contraction_mrr"`
+	ExpansionMRR     float64 `json:"expansion_mrr"`
+	EndMRR           float64 `json:"end_mrr"`
+	retentionRates
+}
+
+func newRetentionData(r *db.Retention) retentionData {
+	return retentionData{
+		From:             r.From,
+		To:               r.To,
+		Months:           r.Months,
+		StartCustomers:   r.StartCustomers,
+		ChurnedCustomers: r.ChurnedCustomers,
+		StartMRR:         float64(r.StartMRR) / 100.0,
+		ChurnedMRR:       float64(r.ChurnedMRR) / 100.0,
+		ContractionMRR:   float64(r.ContractionMRR) / 100.0,
+		ExpansionMRR:     float64(r.ExpansionMRR) / 100.0,
+		EndMRR:           float64(r.EndMRR) / 100.0,
+		retentionRates:   newRetentionRates(r),
+	}
+}
+
+type metricsOutput struct {
+	Month    string          `json:"month"`
+	Currency string          `json:"currency"`
+	Monthly  []retentionData `json:"monthly"`
+	Trailing []retentionData `json:"trailing"`
+}
+
+// trailingRetention computes retention over the months trailing up to and
+// including month
+func trailingRetention(s db.Store, month string, months int) (*db.Retention, error) {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+	return db.GetRetention(s, t.AddDate(0, -(months-1), 0).Format("2006-01"), month)
+}
+
+func buildMetrics(s db.Store, month string, months int) (metricsOutput, error) {
+	output := metricsOutput{
+		Month:    month,
+		Currency: s.ReportingCurrency(),
+		Monthly:  []retentionData{},
+		Trailing: []retentionData{},
+	}
+
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return output, fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+	for i := months - 1; i >= 0; i-- {
+		m := t.AddDate(0, -i, 0).Format("2006-01")
+		r, err := db.GetRetention(s, m, m)
+		if err != nil {
+			return output, err
+		}
+		output.Monthly = append(output.Monthly, newRetentionData(r))
+	}
+
+	for _, period := range trailingPeriods {
+		r, err := trailingRetention(s, month, period)
+		if err != nil {
+			return output, err
+		}
+		output.Trailing = append(output.Trailing, newRetentionData(r))
+	}
+
+	return output, nil
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	month := metricsMonth
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	if metricsMonths < 1 {
+		return fmt.Errorf("--months must be at least 1")
+	}
+
+	output, err := buildMetrics(store, month, metricsMonths)
+	if err != nil {
+		return err
+	}
+
+	if metricsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	hasCustomers := false
+	for _, r := range append(output.Monthly, output.Trailing...) {
+		if r.StartCustomers > 0 {
+			hasCustomers = true
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan("Churn & Retention"))
+	fmt.Println()
+
+	if !hasCustomers {
+		fmt.Printf("  %s No paying customers in these periods. Retention is computed from subscriptions ('mrr sub add').\n\n", yellow("⚠"))
+		return nil
+	}
+
+	fmt.Printf("  %s\n", bold("Monthly:"))
+	printRetentionTable("Month", output.Monthly, func(r retentionData) string {
+		return r.To
+	})
+
+	fmt.Printf("  %s\n", bold("Trailing:"))
+	printRetentionTable("Period", output.Trailing, func(r retentionData) string {
+		return fmt.Sprintf("%d months (%s – %s)", r.Months, r.From, r.To)
+	})
+
+	return nil
+}
+
+// printRetentionTable prints one row of rates per period
+func printRetentionTable(header string, rows []retentionData, label func(retentionData) string) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{header, "Customers", "Churned", "Logo Churn", "MRR Churn", "GRR", "NRR"})
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, 7)
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, r := range rows {
+		nrrColor := tablewriter.Colors{}
+		if r.NRR != nil && *r.NRR < 100 {
+			nrrColor = tablewriter.Colors{tablewriter.FgRedColor}
+		} else if r.NRR != nil {
+			nrrColor = tablewriter.Colors{tablewriter.FgGreenColor}
+		}
+		logoChurnColor, mrrChurnColor := tablewriter.Colors{}, tablewriter.Colors{}
+		if r.LogoChurnRate != nil && *r.LogoChurnRate > 0 {
+			logoChurnColor = tablewriter.Colors{tablewriter.FgRedColor}
+		}
+		if r.GrossMRRChurnRate != nil && *r.GrossMRRChurnRate > 0 {
+			mrrChurnColor = tablewriter.Colors{tablewriter.FgRedColor}
+		}
+
+		table.Rich([]string{
+			label(r),
+			fmt.Sprintf("%d", r.StartCustomers),
+			fmt.Sprintf("%d", r.ChurnedCustomers),
+			formatRate(r.LogoChurnRate),
+			formatRate(r.GrossMRRChurnRate),
+			formatRate(r.GRR),
+			formatRate(r.NRR),
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{},
+			logoChurnColor,
+			mrrChurnColor,
+			{},
+			nrrColor,
+		})
+	}
+
+	table.Render()
+	fmt.Println()
+}
+
+// formatRate formats a percentage, or a dash if there is none
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f%%", *rate)
+}
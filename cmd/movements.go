@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	movementsFrom string
+	movementsTo   string
+	movementsJSON bool
+)
+
+var movementsCmd = &cobra.Command{
+	Use:   "movements",
+	Short: "Show the MRR bridge per month",
+	Long: `Show how MRR moved each month, broken down into new, expansion,
+contraction, churned and reactivated MRR. Adjustments are changes in
+recurring entries added with 'mrr add'. Customers are compared at each
+month's exchange rates; FX is how much those moved subscription MRR.
+
+Examples:
+  mrr movements                              # Last 6 months
+  mrr movements --from 2026-01 --to 2026-06
+  mrr movements --json`,
+	RunE: runMovements,
+}
+
+func init() {
+	movementsCmd.Flags().StringVar(&movementsFrom, "from", "", "First month (YYYY-MM, defaults to 5 months ago)")
+	movementsCmd.Flags().StringVar(&movementsTo, "to", "", "Last month (YYYY-MM, defaults to current)")
+	movementsCmd.Flags().BoolVarP(&movementsJSON, "json", "j", false, "Output as JSON")
+}
+
+type movementData struct {
+	Month            string  `json:"month"`
+	Currency         string  `json:"currency"`
+	StartMRR         float64 `json:"start_mrr"`
+	New              float64 `json:"new"`
+	Expansion        float64 `json:"expansion"`
+	Contraction      float64 `json:"contraction"`
+	Churn            float64 `json:"churn"`
+	Reactivation     float64 `json:"reactivation"`
+	Adjustment       float64 `json:"adjustment"`
+	FX               float64 `json:"fx"`
+	NetNew           float64 `json:"net_new"`
+	EndMRR           float64 `json:"end_mrr"`
+	NewCustomers     int     `json:"new_customers"`
+	ChurnedCustomers int     `json:"churned_customers"`
+}
+
+func newMovementData(m *db.MRRMovement, currency string) *movementData {
+	return &movementData{
+		Month:            m.Month,
+		Currency:         currency,
+		StartMRR:         float64(m.StartMRR) / 100.0,
+		New:              float64(m.New) / 100.0,
+		Expansion:        float64(m.Expansion) / 100.0,
+		Contraction:      float64(m.Contraction) / 100.0,
+		Churn:            float64(m.Churn) / 100.0,
+		Reactivation:     float64(m.Reactivation) / 100.0,
+		Adjustment:       float64(m.Adjustment) / 100.0,
+		FX:               float64(m.FX) / 100.0,
+		NetNew:           float64(m.NetNew()) / 100.0,
+		EndMRR:           float64(m.EndMRR) / 100.0,
+		NewCustomers:     m.NewCustomers,
+		ChurnedCustomers: m.ChurnedCustomers,
+	}
+}
+
+func runMovements(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	to := movementsTo
+	if to == "" {
+		to = time.Now().Format("2006-01")
+	}
+	from := movementsFrom
+	if from == "" {
+		t, err := time.Parse("2006-01", to)
+		if err != nil {
+			return fmt.Errorf("invalid month format, use YYYY-MM: %s", to)
+		}
+		from = t.AddDate(0, -5, 0).Format("2006-01")
+	}
+
+	movements, err := db.GetMRRMovements(store, from, to)
+	if err != nil {
+		return err
+	}
+
+	data := []movementData{}
+	for i := range movements {
+		data = append(data, *newMovementData(&movements[i], store.ReportingCurrency()))
+	}
+
+	if movementsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	currency := store.ReportingCurrency()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Month", "Start", "New", "Expansion", "Reactivation", "Contraction", "Churn", "Adjustment", "FX", "Net", "End"})
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, 11)
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, m := range movements {
+		netColor := tablewriter.FgGreenColor
+		if m.NetNew() < 0 {
+			netColor = tablewriter.FgRedColor
+		}
+
+		table.Rich([]string{
+			m.Month,
+			models.FormatAmount(m.StartMRR, currency),
+			models.FormatAmount(m.New, currency),
+			models.FormatAmount(m.Expansion, currency),
+			models.FormatAmount(m.Reactivation, currency),
+			models.FormatAmount(-m.Contraction, currency),
+			models.FormatAmount(-m.Churn, currency),
+			models.FormatAmount(m.Adjustment, currency),
+			models.FormatAmount(m.FX, currency),
+			models.FormatAmount(m.NetNew(), currency),
+			models.FormatAmount(m.EndMRR, currency),
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgRedColor},
+			{tablewriter.FgRedColor},
+			{tablewriter.FgYellowColor},
+			{tablewriter.FgYellowColor},
+			{netColor},
+			{tablewriter.Bold},
+		})
+	}
+
+	table.Render()
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\n%s months from %s to %s\n", cyan(fmt.Sprintf("%d", len(movements))), from, to)
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	pnlMonth      string
+	pnlCash       string
+	pnlBurnMonths int
+	pnlJSON       bool
+)
+
+var pnlCmd = &cobra.Command{
+	Use:   "pnl",
+	Short: "Show profit and loss, burn and runway",
+	Long: `Show a month's profit and loss: revenue (MRR plus one-time revenue, as in
+'mrr report') against the expenses charged in the month ('mrr expense').
+
+Burn is what the business spends: gross burn is all costs, net burn the
+costs revenue doesn't cover. Runway divides a cash balance by the average
+net burn over the trailing months, where profitable months offset the rest.
+
+Examples:
+  mrr pnl                               # Current month
+  mrr pnl --month 2026-09
+  mrr pnl --cash 25000                  # Runway with $25,000 in the bank
+  mrr pnl --cash 25000 --burn-months 6
+  mrr pnl --json`,
+	Args: cobra.NoArgs,
+	RunE: runPnL,
+}
+
+func init() {
+	pnlCmd.Flags().StringVarP(&pnlMonth, "month", "m", "", "Month (YYYY-MM, defaults to current)")
+	pnlCmd.Flags().StringVar(&pnlCash, "cash", "", "Cash balance for runway, in the reporting currency")
+	pnlCmd.Flags().IntVar(&pnlBurnMonths, "burn-months", 3, "Trailing months net burn is averaged over")
+	pnlCmd.Flags().BoolVarP(&pnlJSON, "json", "j", false, "Output as JSON")
+}
+
+type pnlData struct {
+	Month            string             `json:"month"`
+	Currency         string             `json:"currency"`
+	Revenue          float64            `json:"revenue"`
+	RecurringRevenue float64            `json:"recurring_revenue"`
+	OneTimeRevenue   float64            `json:"one_time_revenue"`
+	Costs            float64            `json:"costs"`
+	RecurringCosts   float64            `json:"recurring_costs"`
+	ByCategory       map[string]float64 `json:"by_category"`
+	NetProfit        float64            `json:"net_profit"`
+	Margin           *float64           `json:"margin,omitempty"` // Percent, nil without revenue
+	GrossBurn        float64            `json:"gross_burn"`
+	NetBurn          float64            `json:"net_burn"`
+	BurnMonths       int                `json:"burn_months"`
+	AverageNetBurn   float64            `json:"average_net_burn"`
+	Cash             *float64           `json:"cash,omitempty"`
+	RunwayMonths     *float64           `json:"runway_months,omitempty"` // nil without cash or burn
+	RunwayUntil      string             `json:"runway_until,omitempty"`  // YYYY-MM the cash runs out
+}
+
+// buildPnL computes the profit and loss of month from s, with net burn
+// averaged over the burnMonths trailing months and runway from cash (in
+// cents, nil if unknown)
+func buildPnL(s db.Store, month string, burnMonths int, cash *int64) (pnlData, error) {
+	p, err := db.GetProfitAndLoss(s, month)
+	if err != nil {
+		return pnlData{}, err
+	}
+
+	data := pnlData{
+		Month:            month,
+		Currency:         p.Currency,
+		Revenue:          float64(p.Revenue) / 100.0,
+		RecurringRevenue: float64(p.RecurringRevenue) / 100.0,
+		OneTimeRevenue:   float64(p.OneTimeRevenue) / 100.0,
+		Costs:            float64(p.Costs) / 100.0,
+		RecurringCosts:   float64(p.RecurringCosts) / 100.0,
+		ByCategory:       make(map[string]float64),
+		NetProfit:        float64(p.NetProfit()) / 100.0,
+		GrossBurn:        float64(p.Costs) / 100.0,
+		NetBurn:          float64(p.NetBurn()) / 100.0,
+		BurnMonths:       burnMonths,
+	}
+	for category, amount := range p.ByCategory {
+		data.ByCategory[category] = float64(amount) / 100.0
+	}
+	if p.Revenue != 0 {
+		margin := p.Margin() * 100
+		data.Margin = &margin
+	}
+
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return pnlData{}, fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+	var totalBurn int64
+	for i := 0; i < burnMonths; i++ {
+		prev, err := db.GetProfitAndLoss(s, t.AddDate(0, -i, 0).Format("2006-01"))
+		if err != nil {
+			return pnlData{}, err
+		}
+		totalBurn += prev.Costs - prev.Revenue
+	}
+	// Profitable months offset the others; a business that made money
+	// overall isn't burning
+	averageBurn := math.Max(0, float64(totalBurn)/float64(burnMonths)/100.0)
+	data.AverageNetBurn = math.Round(averageBurn*100) / 100
+
+	if cash != nil {
+		balance := float64(*cash) / 100.0
+		data.Cash = &balance
+		if averageBurn > 0 {
+			runway := balance / averageBurn
+			data.RunwayMonths = &runway
+			data.RunwayUntil = t.AddDate(0, int(runway), 0).Format("2006-01")
+		}
+	}
+
+	return data, nil
+}
+
+func runPnL(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	month := pnlMonth
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	if pnlBurnMonths < 1 {
+		return fmt.Errorf("--burn-months must be at least 1")
+	}
+
+	var cash *int64
+	if pnlCash != "" {
+		amountFloat, err := strconv.ParseFloat(strings.TrimPrefix(pnlCash, "$"), 64)
+		if err != nil || amountFloat < 0 {
+			return fmt.Errorf("invalid cash balance: %s", pnlCash)
+		}
+		cents := int64(math.Round(amountFloat * 100))
+		cash = &cents
+	}
+
+	data, err := buildPnL(store, month, pnlBurnMonths, cash)
+	if err != nil {
+		return err
+	}
+
+	if pnlJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	printPnL(data)
+	return nil
+}
+
+func printPnL(data pnlData) {
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	amount := func(v float64) string {
+		return models.FormatAmount(int64(math.Round(v*100)), data.Currency)
+	}
+
+	t, _ := time.Parse("2006-01", data.Month)
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("Profit & Loss: %s", t.Format("January 2006"))))
+	fmt.Println("  " + strings.Repeat("─", 35))
+	fmt.Println()
+
+	if data.Revenue == 0 && data.Costs == 0 {
+		fmt.Printf("  %s No revenue or expenses for this month. Add expenses with 'mrr expense add'.\n\n", yellow("⚠"))
+		return
+	}
+
+	fmt.Printf("  %s       %s\n", bold("Revenue:"), green(amount(data.Revenue)))
+	fmt.Printf("    Recurring:   %s\n", amount(data.RecurringRevenue))
+	fmt.Printf("    One-time:    %s\n", amount(data.OneTimeRevenue))
+	fmt.Printf("  %s         %s\n", bold("Costs:"), red(amount(data.Costs)))
+
+	categories := make([]string, 0, len(data.ByCategory))
+	for category := range data.ByCategory {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return data.ByCategory[categories[i]] > data.ByCategory[categories[j]]
+	})
+	for _, category := range categories {
+		share := 0.0
+		if data.Costs > 0 {
+			share = data.ByCategory[category] / data.Costs * 100
+		}
+		fmt.Printf("    %-12s %s (%.1f%%)\n", category+":", amount(data.ByCategory[category]), share)
+	}
+	fmt.Println()
+
+	profit := amount(data.NetProfit)
+	if data.NetProfit >= 0 {
+		profit = green(profit)
+	} else {
+		profit = red(profit)
+	}
+	fmt.Printf("  %s    %s\n", bold("Net profit:"), profit)
+	fmt.Printf("  %s        %s\n", bold("Margin:"), formatRate(data.Margin))
+	fmt.Println()
+
+	fmt.Printf("  %s\n", bold("Burn:"))
+	fmt.Printf("    Gross burn:  %s/month\n", amount(data.GrossBurn))
+	if data.NetBurn > 0 {
+		fmt.Printf("    Net burn:    %s/month\n", red(amount(data.NetBurn)))
+	} else {
+		fmt.Printf("    Net burn:    %s\n", green("none, revenue covers costs"))
+	}
+	fmt.Printf("    Average:     %s/month over %d months\n", amount(data.AverageNetBurn), data.BurnMonths)
+
+	switch {
+	case data.Cash == nil:
+		fmt.Printf("    Runway:      — (pass your cash balance with --cash)\n")
+	case data.RunwayMonths == nil:
+		fmt.Printf("    Runway:      %s\n", green(fmt.Sprintf("unlimited, not burning %s", amount(*data.Cash))))
+	default:
+		runway := fmt.Sprintf("%.1f months (until %s)", *data.RunwayMonths, data.RunwayUntil)
+		if *data.RunwayMonths < 6 {
+			runway = red(runway)
+		} else if *data.RunwayMonths < 12 {
+			runway = yellow(runway)
+		} else {
+			runway = green(runway)
+		}
+		fmt.Printf("    Runway:      %s with %s\n", runway, amount(*data.Cash))
+	}
+	fmt.Println()
+}
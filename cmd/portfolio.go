@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+type portfolioWorkspace struct {
+	Workspace  string   `json:"workspace"`
+	Currency   string   `json:"currency"`
+	MRR        float64  `json:"mrr"`
+	ARR        float64  `json:"arr"`
+	GrowthRate *float64 `json:"growth_rate,omitempty"`
+	EntryCount int      `json:"entry_count"`
+	SubCount   int      `json:"subscription_count"`
+}
+
+type portfolioTotal struct {
+	Currency string  `json:"currency"`
+	MRR      float64 `json:"mrr"`
+	ARR      float64 `json:"arr"`
+}
+
+type portfolioData struct {
+	Month      string               `json:"month"`
+	Workspaces []portfolioWorkspace `json:"workspaces"`
+	// Totals are per reporting currency since each workspace keeps its own
+	// FX table
+	Totals []portfolioTotal `json:"totals"`
+}
+
+// runPortfolioReport reports MRR for every workspace and totals them
+func runPortfolioReport(cmd *cobra.Command, month string) error {
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+
+	if storeInjected(cmd) {
+		return fmt.Errorf("--all-workspaces is not available with an embedded store")
+	}
+
+	names, err := listWorkspaces()
+	if err != nil {
+		return err
+	}
+
+	// Reopen the selected workspace afterwards so PersistentPostRun closes it
+	defer openDatabase(cmd, true)
+
+	data := portfolioData{Month: month, Workspaces: []portfolioWorkspace{}, Totals: []portfolioTotal{}}
+	totals := make(map[string]int64)
+
+	for _, name := range names {
+		if _, err := openWorkspace(cmd, name); err != nil {
+			return err
+		}
+		store := storeFrom(cmd)
+
+		report, err := db.GetMonthlyReport(store, month)
+		if err != nil {
+			return fmt.Errorf("workspace %s: %w", name, err)
+		}
+
+		mrr := float64(report.RecurringRevenue) / 100.0
+		ws := portfolioWorkspace{
+			Workspace:  name,
+			Currency:   report.Currency,
+			MRR:        mrr,
+			ARR:        mrr * 12,
+			EntryCount: report.EntryCount,
+			SubCount:   report.SubscriptionCount,
+		}
+
+		prevMRR, err := db.GetPreviousMonthMRR(store, month)
+		if err == nil && prevMRR > 0 {
+			growthRate := float64(report.RecurringRevenue-prevMRR) / float64(prevMRR) * 100
+			ws.GrowthRate = &growthRate
+		}
+
+		data.Workspaces = append(data.Workspaces, ws)
+		totals[report.Currency] += report.RecurringRevenue
+	}
+	closeStore(cmd)
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		mrr := float64(totals[currency]) / 100.0
+		data.Totals = append(data.Totals, portfolioTotal{Currency: currency, MRR: mrr, ARR: mrr * 12})
+	}
+
+	if reportJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	return printPortfolio(data)
+}
+
+func printPortfolio(data portfolioData) error {
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	t, _ := time.Parse("2006-01", data.Month)
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("Portfolio Report: %s", t.Format("January 2006"))))
+	fmt.Println("  " + strings.Repeat("─", 35))
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Workspace", "MRR", "ARR", "Growth", "Entries", "Subs"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, w := range data.Workspaces {
+		growth := "-"
+		growthColor := tablewriter.FgYellowColor
+		if w.GrowthRate != nil {
+			growth = fmt.Sprintf("%+.1f%%", *w.GrowthRate)
+			if *w.GrowthRate > 0 {
+				growthColor = tablewriter.FgGreenColor
+			} else if *w.GrowthRate < 0 {
+				growthColor = tablewriter.FgRedColor
+			}
+		}
+
+		table.Rich([]string{
+			w.Workspace,
+			models.FormatAmount(int64(w.MRR*100), w.Currency),
+			models.FormatAmount(int64(w.ARR*100), w.Currency),
+			growth,
+			fmt.Sprintf("%d", w.EntryCount),
+			fmt.Sprintf("%d", w.SubCount),
+		}, []tablewriter.Colors{
+			{tablewriter.FgMagentaColor},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgGreenColor},
+			{growthColor},
+			{},
+			{},
+		})
+	}
+
+	table.Render()
+	fmt.Println()
+
+	for _, total := range data.Totals {
+		fmt.Printf("  %s  %s MRR, %s ARR\n",
+			bold(fmt.Sprintf("Total (%s):", total.Currency)),
+			green(models.FormatAmount(int64(total.MRR*100), total.Currency)),
+			green(models.FormatAmount(int64(total.ARR*100), total.Currency)),
+		)
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Privacy levels for the amounts on the public dashboard
+const (
+	AmountsExact    = "exact"    // Amounts as they are
+	AmountsRounded  = "rounded"  // Amounts rounded to two significant digits
+	AmountsBucketed = "bucketed" // Ranges such as $5k–10k instead of amounts
+	AmountsGrowth   = "growth"   // No amounts, only growth percentages
+)
+
+// ValidAmountLevels contains all valid amount privacy levels
+var ValidAmountLevels = []string{AmountsExact, AmountsRounded, AmountsBucketed, AmountsGrowth}
+
+// PrivacyConfig controls what the public dashboard, its JSON data, badges
+// and published sites reveal
+type PrivacyConfig struct {
+	Amounts     string `json:"amounts,omitempty"`      // One of ValidAmountLevels, default exact
+	HideSources bool   `json:"hide_sources,omitempty"` // Leave out the MRR by source
+	DelayDays   int    `json:"delay_days,omitempty"`   // Show the data as it was this many days ago
+}
+
+var (
+	privacyAmounts     string
+	privacyHideSources bool
+	privacyDelay       int
+	privacyJSON        bool
+)
+
+var privacyCmd = &cobra.Command{
+	Use:   "privacy",
+	Short: "Control what the public dashboard reveals",
+	Long: `Show or change what the public dashboard reveals.
+
+The settings apply to 'mrr serve --public' (the page and /api/data),
+'mrr publish' and 'mrr badge'. The private dashboard always shows
+everything.
+
+Amount levels:
+  exact      Amounts as they are (default)
+  rounded    Amounts rounded to two significant digits, e.g. ≈ $12k
+  bucketed   Ranges instead of amounts, e.g. $5k–10k
+  growth     No amounts at all, only growth percentages
+
+Examples:
+  mrr privacy                                  # Show the current settings
+  mrr privacy set --amounts bucketed
+  mrr privacy set --hide-sources --delay 30    # Publish month-old data
+  mrr privacy reset`,
+	Args: cobra.NoArgs,
+	RunE: runPrivacyShow,
+}
+
+var privacySetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change privacy settings",
+	Long: `Change privacy settings. Settings not given are left as they are.
+
+Examples:
+  mrr privacy set --amounts rounded
+  mrr privacy set --hide-sources
+  mrr privacy set --hide-sources=false --delay 0`,
+	Args: cobra.NoArgs,
+	RunE: runPrivacySet,
+}
+
+var privacyResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Publish exact, current data again",
+	Args:  cobra.NoArgs,
+	RunE:  runPrivacyReset,
+}
+
+func init() {
+	privacyCmd.Flags().BoolVarP(&privacyJSON, "json", "j", false, "Output as JSON")
+
+	privacySetCmd.Flags().StringVar(&privacyAmounts, "amounts", "", fmt.Sprintf("How amounts are shown (%s)", strings.Join(ValidAmountLevels, ", ")))
+	privacySetCmd.Flags().BoolVar(&privacyHideSources, "hide-sources", false, "Hide the MRR by source")
+	privacySetCmd.Flags().IntVar(&privacyDelay, "delay", 0, "Show the data as it was this many days ago")
+
+	privacyCmd.AddCommand(privacySetCmd)
+	privacyCmd.AddCommand(privacyResetCmd)
+}
+
+// loadPrivacy returns the workspace's privacy settings
+func loadPrivacy() (PrivacyConfig, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return PrivacyConfig{}, err
+	}
+	if config.Privacy == nil {
+		return PrivacyConfig{}, nil
+	}
+	return *config.Privacy, nil
+}
+
+func runPrivacyShow(cmd *cobra.Command, args []string) error {
+	privacy, err := loadPrivacy()
+	if err != nil {
+		return err
+	}
+	privacy.Amounts = privacy.amounts()
+
+	if privacyJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(privacy)
+	}
+
+	printPrivacy(privacy)
+	return nil
+}
+
+func runPrivacySet(cmd *cobra.Command, args []string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	privacy := PrivacyConfig{}
+	if config.Privacy != nil {
+		privacy = *config.Privacy
+	}
+
+	if cmd.Flags().Changed("amounts") {
+		if !isValidAmountLevel(privacyAmounts) {
+			return fmt.Errorf("invalid amounts level: %s (valid: %v)", privacyAmounts, ValidAmountLevels)
+		}
+		privacy.Amounts = privacyAmounts
+	}
+	if cmd.Flags().Changed("hide-sources") {
+		privacy.HideSources = privacyHideSources
+	}
+	if cmd.Flags().Changed("delay") {
+		if privacyDelay < 0 {
+			return fmt.Errorf("delay must not be negative")
+		}
+		privacy.DelayDays = privacyDelay
+	}
+
+	config.Privacy = &privacy
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Privacy settings saved\n\n", green("✓"))
+	privacy.Amounts = privacy.amounts()
+	printPrivacy(privacy)
+
+	return nil
+}
+
+func runPrivacyReset(cmd *cobra.Command, args []string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	config.Privacy = nil
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Privacy settings reset: exact, current data with sources\n", green("✓"))
+	return nil
+}
+
+func printPrivacy(privacy PrivacyConfig) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	sources := "shown"
+	if privacy.HideSources {
+		sources = "hidden"
+	}
+	delay := "none"
+	if privacy.DelayDays > 0 {
+		delay = fmt.Sprintf("%d days (data as of %s)", privacy.DelayDays, privacy.asOf().Format("2006-01-02"))
+	}
+
+	fmt.Printf("  Amounts:  %s\n", cyan(privacy.Amounts))
+	fmt.Printf("  Sources:  %s\n", cyan(sources))
+	fmt.Printf("  Delay:    %s\n", cyan(delay))
+}
+
+// isValidAmountLevel checks if an amount privacy level is valid
+func isValidAmountLevel(level string) bool {
+	for _, l := range ValidAmountLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// amounts returns the amount privacy level, exact if unset
+func (p PrivacyConfig) amounts() string {
+	if p.Amounts == "" {
+		return AmountsExact
+	}
+	return p.Amounts
+}
+
+// asOf returns the time the published data is from
+func (p PrivacyConfig) asOf() time.Time {
+	return time.Now().AddDate(0, 0, -p.DelayDays)
+}
+
+// view returns the store as it stood at the time the published data is from
+func (p PrivacyConfig) view(s db.Store) db.Store {
+	if p.DelayDays <= 0 {
+		return s
+	}
+	return db.AsOf(s, p.asOf())
+}
+
+// publicDashboardData builds the public dashboard for a YYYY-MM month, or
+// for the month the published data is from if month is empty, and applies
+// the privacy settings to it
+func publicDashboardData(s db.Store, privacy PrivacyConfig, month string) (*dashboardData, error) {
+	if month == "" {
+		month = privacy.asOf().Format("2006-01")
+	}
+
+	data, err := getMonthDashboardData(privacy.view(s), true, month)
+	if err != nil {
+		return nil, err
+	}
+	privacy.apply(data)
+	return data, nil
+}
+
+// apply removes what the settings don't allow to be published from data
+func (p PrivacyConfig) apply(data *dashboardData) {
+	if p.DelayDays > 0 {
+		data.AsOf = p.asOf().Format("2006-01-02")
+	}
+	if p.HideSources {
+		data.Sources = nil
+	}
+
+	level := p.amounts()
+	if level == AmountsExact {
+		return
+	}
+	data.Privacy = level
+
+	// The bridge adds the exact MRR back up
+	data.Movements = nil
+	for i := range data.Sources {
+		data.Sources[i].Share = math.Round(data.Sources[i].Share)
+	}
+
+	switch level {
+	case AmountsRounded:
+		mrr := roundSignificant(*data.CurrentMRR, 2)
+		arr := mrr * 12
+		data.CurrentMRR, data.ARR = &mrr, &arr
+		for i := range data.MonthlyTrend {
+			rounded := roundSignificant(*data.MonthlyTrend[i].MRR, 2)
+			data.MonthlyTrend[i].MRR = &rounded
+		}
+		for i := range data.Sources {
+			rounded := roundSignificant(*data.Sources[i].MRR, 2)
+			data.Sources[i].MRR = &rounded
+		}
+		if data.Goal != nil {
+			data.Goal.Progress = math.Round(data.Goal.Progress)
+		}
+
+	case AmountsBucketed:
+		data.MRRRange = newAmountRange(*data.CurrentMRR, 1, data.Currency)
+		data.ARRRange = newAmountRange(*data.CurrentMRR, 12, data.Currency)
+		data.CurrentMRR, data.ARR = nil, nil
+		for i := range data.MonthlyTrend {
+			data.MonthlyTrend[i].Range = newAmountRange(*data.MonthlyTrend[i].MRR, 1, data.Currency)
+			data.MonthlyTrend[i].MRR = nil
+		}
+		for i := range data.Sources {
+			data.Sources[i].MRR = nil
+		}
+		if data.Goal != nil {
+			data.Goal.Progress = math.Floor(data.Goal.Progress/10) * 10
+		}
+
+	case AmountsGrowth:
+		data.CurrentMRR, data.ARR = nil, nil
+		for i := range data.MonthlyTrend {
+			data.MonthlyTrend[i].MRR = nil
+		}
+		for i := range data.Sources {
+			data.Sources[i].MRR = nil
+		}
+		// Progress towards a known goal gives the MRR away
+		data.Goal = nil
+	}
+}
+
+// amountRange is the bucket an amount falls in
+type amountRange struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Label string  `json:"label"` // e.g. $5k–10k
+}
+
+// newAmountRange returns the bucket of a monthly amount, scaled by
+// multiplier so ARR falls in the matching bucket. Buckets follow the 1-2-5
+// series: 0–100, 100–200, 200–500, 500–1k, 1k–2k and so on.
+func newAmountRange(amount, multiplier float64, currency string) *amountRange {
+	min, max := 0.0, 100.0
+	if amount >= 100 {
+		decade := 100.0
+		for decade*10 <= amount {
+			decade *= 10
+		}
+		for _, step := range []float64{1, 2, 5} {
+			if amount >= step*decade {
+				min = step * decade
+			}
+		}
+		switch min / decade {
+		case 1:
+			max = 2 * decade
+		case 2:
+			max = 5 * decade
+		default:
+			max = 10 * decade
+		}
+	}
+
+	min, max = min*multiplier, max*multiplier
+	return &amountRange{
+		Min:   min,
+		Max:   max,
+		Label: models.FormatAmountRange(int64(min*100), int64(max*100), currency),
+	}
+}
+
+// roundSignificant rounds v to the given number of significant digits
+func roundSignificant(v float64, digits int) float64 {
+	if v == 0 {
+		return 0
+	}
+	scale := math.Pow(10, float64(digits)-math.Ceil(math.Log10(math.Abs(v))))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// NewMetricsHandler returns the handler for /metrics, exporting the
+// dashboard's figures as Prometheus gauges. In public mode per-source MRR
+// is left out, as it is on the dashboard.
+func NewMetricsHandler(s db.Store, public bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := renderMetrics(s, public)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", metricsContentType)
+		w.Write(body)
+	})
+}
+
+// renderMetrics computes the metrics from the same data as the dashboard
+func renderMetrics(s db.Store, public bool) ([]byte, error) {
+	data, err := getDashboardData(s, public)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.ListEntries("", "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var m metricsWriter
+	currency := map[string]string{"currency": data.Currency}
+
+	m.gauge("mrr_current", "Monthly recurring revenue this month, in the reporting currency.")
+	m.sample(currency, *data.CurrentMRR)
+
+	m.gauge("mrr_arr", "Annual run rate (MRR x 12), in the reporting currency.")
+	m.sample(currency, *data.ARR)
+
+	if data.GrowthRate != nil {
+		m.gauge("mrr_growth_rate_percent", "MRR growth over the previous month, in percent.")
+		m.sample(nil, *data.GrowthRate)
+	}
+
+	if !public {
+		m.gauge("mrr_source", "Monthly recurring revenue this month by source, in the reporting currency.")
+		for _, source := range sortedKeys(data.report.MRRBySource) {
+			m.sample(map[string]string{"source": source, "currency": data.Currency},
+				float64(data.report.MRRBySource[source])/100.0)
+		}
+	}
+
+	if data.Goal != nil {
+		m.gauge("mrr_goal", "MRR goal, in the reporting currency.")
+		m.sample(currency, data.Goal.Amount)
+
+		m.gauge("mrr_goal_progress_ratio", "Progress towards the MRR goal, from 0 to 1.")
+		m.sample(nil, data.Goal.Progress/100)
+	}
+
+	m.gauge("mrr_subscriptions_active", "Subscriptions active at the end of this month.")
+	m.sample(nil, float64(data.report.SubscriptionCount))
+
+	m.gauge("mrr_month_entries", "Revenue entries dated this month.")
+	m.sample(nil, float64(data.report.EntryCount))
+
+	counts := make(map[[2]string]int)
+	for _, e := range entries {
+		counts[[2]string{e.Type, e.Origin}]++
+	}
+	keys := make([][2]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	m.gauge("mrr_entries", "Revenue entries recorded, by type and origin.")
+	for _, key := range keys {
+		m.sample(map[string]string{"type": key[0], "origin": key[1]}, float64(counts[key]))
+	}
+
+	return m.buf.Bytes(), nil
+}
+
+// metricsWriter writes gauges in the Prometheus text format
+type metricsWriter struct {
+	buf  bytes.Buffer
+	name string
+}
+
+// gauge starts a metric; the samples written after it belong to it
+func (m *metricsWriter) gauge(name, help string) {
+	m.name = name
+	fmt.Fprintf(&m.buf, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// sample writes a sample of the current metric with the given labels
+func (m *metricsWriter) sample(labels map[string]string, value float64) {
+	m.buf.WriteString(m.name)
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels))
+		for _, name := range sortedKeys(labels) {
+			pairs = append(pairs, name+`="`+escapeLabel(labels[name])+`"`)
+		}
+		m.buf.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+	m.buf.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+// escapeLabel escapes a label value for the text format
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// sortedKeys returns a map's keys in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+var (
+	publishOut  string
+	publishJSON bool
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Render the public dashboard to static files",
+	Long: `Render the public dashboard to a directory of static files that can be
+pushed to any static host, e.g. from a cron job.
+
+The site holds the dashboard as served by 'mrr serve --public', its JSON data,
+SVG badges and a page for every month since the first entry or subscription.
+What is published follows the privacy settings (see 'mrr privacy'):
+
+  index.html              Dashboard for the current month
+  data.json               The dashboard's data, as served at /api/data
+  badges/mrr.svg          MRR, ARR, growth and goal badges
+  history/index.html      MRR of every month
+  history/YYYY-MM.html    Dashboard as of the end of each month
+  history/YYYY-MM.json
+
+Files from an earlier run are overwritten; other files are left alone, except
+history pages of months a delay (see 'mrr privacy') no longer publishes.
+
+Examples:
+  mrr publish                     # Publish to ./site
+  mrr publish --out ./public
+  mrr publish --json              # List the files written as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runPublish,
+}
+
+func init() {
+	publishCmd.Flags().StringVarP(&publishOut, "out", "o", "site", "Output directory")
+	publishCmd.Flags().BoolVarP(&publishJSON, "json", "j", false, "Output the result as JSON")
+}
+
+type publishOutput struct {
+	Out    string   `json:"out"`
+	Months int      `json:"months"`
+	Files  []string `json:"files"`
+}
+
+// sitePublisher writes the files of a static site under a directory
+type sitePublisher struct {
+	out   string
+	files []string
+}
+
+func (p *sitePublisher) write(name string, content []byte) error {
+	path := filepath.Join(p.out, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	p.files = append(p.files, name)
+	return nil
+}
+
+func (p *sitePublisher) writeJSON(name string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return p.write(name, append(content, '\n'))
+}
+
+// removeHistoryAfter removes the history pages of months after last, left
+// over from runs with a shorter delay
+func (p *sitePublisher) removeHistoryAfter(last string) error {
+	pages, err := filepath.Glob(filepath.Join(p.out, "history", "[0-9][0-9][0-9][0-9]-[0-9][0-9].*"))
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		name := filepath.Base(page)
+		if ext := filepath.Ext(name); (ext == ".html" || ext == ".json") && strings.TrimSuffix(name, ext) > last {
+			if err := os.Remove(page); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", page, err)
+			}
+		}
+	}
+	return nil
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	p := &sitePublisher{out: publishOut}
+
+	privacy, err := loadPrivacy()
+	if err != nil {
+		return err
+	}
+
+	data, err := publicDashboardData(store, privacy, "")
+	if err != nil {
+		return err
+	}
+	data.historyURL = "history/index.html"
+	if err := p.write("index.html", []byte(generateHTML(store, data))); err != nil {
+		return err
+	}
+	if err := p.writeJSON("data.json", data); err != nil {
+		return err
+	}
+
+	badges := dashboardBadges(data)
+	for _, name := range sortedKeys(badges) {
+		if err := p.write("badges/"+name+".svg", []byte(badges[name])); err != nil {
+			return err
+		}
+	}
+
+	months, err := publishMonths(store, data.Month)
+	if err != nil {
+		return err
+	}
+
+	history := []monthlyDataPoint{}
+	for _, month := range months {
+		monthData, err := publicDashboardData(store, privacy, month)
+		if err != nil {
+			return err
+		}
+		monthData.historyURL = "index.html"
+		monthData.LastUpdated = data.LastUpdated
+
+		if err := p.write("history/"+month+".html", []byte(generateHTML(store, monthData))); err != nil {
+			return err
+		}
+		if err := p.writeJSON("history/"+month+".json", monthData); err != nil {
+			return err
+		}
+		history = append(history, monthlyDataPoint{
+			Month:  month,
+			MRR:    monthData.CurrentMRR,
+			Range:  monthData.MRRRange,
+			Growth: monthData.GrowthRate,
+		})
+	}
+	if err := p.write("history/index.html", []byte(generateHistoryHTML(history, data))); err != nil {
+		return err
+	}
+	if err := p.removeHistoryAfter(data.Month); err != nil {
+		return err
+	}
+
+	if publishJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(publishOutput{Out: publishOut, Months: len(months), Files: p.files})
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("%s Published %d files to %s\n", green("✓"), len(p.files), cyan(publishOut))
+	fmt.Printf("  Dashboard, data.json, %d badges and %d months of history\n", len(badges), len(months))
+
+	return nil
+}
+
+// dashboardBadges renders the badges for a dashboard by file name. When
+// amounts are hidden the MRR badge shows the growth instead.
+func dashboardBadges(data *dashboardData) map[string]string {
+	badges := map[string]string{}
+	if mrr := data.formatAmount(data.CurrentMRR, data.MRRRange); mrr != "" {
+		badges["mrr"] = generateBadgeSVG("MRR", mrr)
+	} else {
+		growth := "n/a"
+		if data.GrowthRate != nil {
+			growth = fmt.Sprintf("%+.1f%%", *data.GrowthRate)
+		}
+		badges["mrr"] = generateBadgeSVG("MRR growth", growth)
+	}
+	if arr := data.formatAmount(data.ARR, data.ARRRange); arr != "" {
+		badges["arr"] = generateBadgeSVG("ARR", arr)
+	}
+	if data.GrowthRate != nil {
+		badges["growth"] = generateBadgeSVG("growth", fmt.Sprintf("%+.1f%%", *data.GrowthRate))
+	}
+	if data.Goal != nil {
+		badges["goal"] = generateBadgeSVG("goal", fmt.Sprintf("%.0f%%", data.Goal.Progress))
+	}
+	return badges
+}
+
+// publishMonths returns every month from the first entry or subscription
+// to the YYYY-MM month last, oldest first
+func publishMonths(s db.Store, last string) ([]string, error) {
+	first := time.Now()
+
+	entries, err := s.ListEntries("", "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Date.Before(first) {
+			first = e.Date
+		}
+	}
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subs {
+		if sub.StartDate.Before(first) {
+			first = sub.StartDate
+		}
+	}
+
+	months := []string{}
+	for t := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); ; t = t.AddDate(0, 1, 0) {
+		month := t.Format("2006-01")
+		months = append(months, month)
+		if month >= last {
+			break
+		}
+	}
+	return months, nil
+}
+
+// generateHistoryHTML renders the page listing every month's MRR, newest
+// first, linking to the month pages. Amounts are formatted like on the
+// dashboard data.
+func generateHistoryHTML(history []monthlyDataPoint, data *dashboardData) string {
+	var rows string
+	for i := len(history) - 1; i >= 0; i-- {
+		point := history[i]
+		t, _ := time.Parse("2006-01", point.Month)
+
+		amount := data.formatAmount(point.MRR, point.Range)
+		if amount == "" {
+			amount = "—"
+		}
+
+		change := ""
+		if point.Growth != nil {
+			class := "gray"
+			if *point.Growth > 0 {
+				class = "green"
+			} else if *point.Growth < 0 {
+				class = "red"
+			}
+			change = fmt.Sprintf(`<span class="%s">%+.1f%%</span>`, class, *point.Growth)
+		}
+
+		rows += fmt.Sprintf(`
+				<tr>
+					<td><a href="%s.html">%s</a></td>
+					<td class="amount">%s</td>
+					<td>%s</td>
+				</tr>`,
+			point.Month,
+			html.EscapeString(t.Format("January 2006")),
+			html.EscapeString(amount),
+			change,
+		)
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>MRR History</title>
+	<style>
+		* {
+			margin: 0;
+			padding: 0;
+			box-sizing: border-box;
+		}
+		body {
+			font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+			background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+			min-height: 100vh;
+			padding: 40px 20px;
+			color: #1a1a2e;
+		}
+		.container {
+			max-width: 600px;
+			margin: 0 auto;
+		}
+		.card {
+			background: white;
+			border-radius: 20px;
+			padding: 40px;
+			box-shadow: 0 20px 60px rgba(0,0,0,0.3);
+		}
+		h3 {
+			font-size: 14px;
+			text-transform: uppercase;
+			letter-spacing: 1px;
+			color: #666;
+			margin-bottom: 15px;
+		}
+		table {
+			width: 100%%;
+			border-collapse: collapse;
+			font-size: 14px;
+		}
+		th {
+			text-align: left;
+			padding: 10px 8px;
+			border-bottom: 2px solid #e2e8f0;
+			color: #666;
+			font-weight: 500;
+		}
+		td {
+			padding: 10px 8px;
+			border-bottom: 1px solid #f0f0f0;
+		}
+		a {
+			color: #667eea;
+			text-decoration: none;
+		}
+		.amount {
+			font-weight: 600;
+		}
+		.green {
+			color: #155724;
+		}
+		.red {
+			color: #721c24;
+		}
+		.gray {
+			color: #4a5568;
+		}
+		.footer {
+			text-align: center;
+			margin-top: 30px;
+			padding-top: 20px;
+			border-top: 1px solid #e2e8f0;
+			color: #999;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="card">
+			<h3>MRR History</h3>
+			<table>
+				<thead>
+					<tr><th>Month</th><th>MRR</th><th>Change</th></tr>
+				</thead>
+				<tbody>%s</tbody>
+			</table>
+
+			<div class="footer">
+				<a href="../index.html">Current dashboard</a><br>
+				Powered by <a href="https://github.com/indiekitai/mrr-cli" target="_blank">mrr-cli</a>
+			</div>
+		</div>
+	</div>
+</body>
+</html>`, rows)
+}
@@ -21,6 +21,10 @@ var (
 	reportMultiplier float64
 	reportJSON       bool
 	reportQuiet      bool
+	reportAll        bool
+	reportFrom       string
+	reportTo         string
+	reportPeriod     string
 )
 
 var reportCmd = &cobra.Command{
@@ -28,12 +32,21 @@ var reportCmd = &cobra.Command{
 	Short: "Generate monthly report",
 	Long: `Generate a monthly revenue report with MRR, ARR, growth rate, and valuation.
 
+With --from, --to or --period, report a time series instead: MRR,
+one-time revenue, growth vs the previous period, QoQ and YoY, and entry
+counts per week, month, quarter or year. The range defaults to the last 12
+periods.
+
 Examples:
   mrr report
   mrr report --month 2024-01
   mrr report --multiplier 5        # Use 5x ARR for valuation
   mrr report --json                # Output as JSON
-  mrr report --quiet               # Output only MRR number`,
+  mrr report --quiet               # Output only MRR number
+  mrr report --all-workspaces      # Portfolio rollup across workspaces
+  mrr report --from 2026-01 --to 2026-09
+  mrr report --period quarter      # Last 12 quarters
+  mrr report --period week --from 2026-08-01 --json`,
 	RunE: runReport,
 }
 
@@ -42,56 +55,99 @@ func init() {
 	reportCmd.Flags().Float64Var(&reportMultiplier, "multiplier", 3.0, "ARR multiplier for valuation (default 3x)")
 	reportCmd.Flags().BoolVarP(&reportJSON, "json", "j", false, "Output as JSON")
 	reportCmd.Flags().BoolVarP(&reportQuiet, "quiet", "q", false, "Output only MRR number")
+	reportCmd.Flags().BoolVar(&reportAll, "all-workspaces", false, "Roll up MRR across all workspaces")
+	reportCmd.Flags().StringVar(&reportFrom, "from", "", "Start of a time series (YYYY-MM or YYYY-MM-DD)")
+	reportCmd.Flags().StringVar(&reportTo, "to", "", "End of a time series (YYYY-MM or YYYY-MM-DD, defaults to today)")
+	reportCmd.Flags().StringVar(&reportPeriod, "period", "month", "Time series granularity (week, month, quarter, year)")
 }
 
 type reportData struct {
-	Month            string             `json:"month"`
-	MRR              float64            `json:"mrr"`
-	ARR              float64            `json:"arr"`
-	OneTimeRevenue   float64            `json:"one_time_revenue"`
-	TotalRevenue     float64            `json:"total_revenue"`
-	GrowthRate       *float64           `json:"growth_rate,omitempty"`
-	PrevMRR          *float64           `json:"prev_mrr,omitempty"`
-	Valuation        float64            `json:"valuation"`
-	Multiplier       float64            `json:"multiplier"`
-	BySource         map[string]float64 `json:"by_source"`
-	BySourcePercent  map[string]float64 `json:"by_source_percent"`
-	EntryCount       int                `json:"entry_count"`
+	Month             string             `json:"month"`
+	Currency          string             `json:"currency"`
+	MRR               float64            `json:"mrr"`
+	ARR               float64            `json:"arr"`
+	OneTimeRevenue    float64            `json:"one_time_revenue"`
+	TotalRevenue      float64            `json:"total_revenue"`
+	GrowthRate        *float64           `json:"growth_rate,omitempty"`
+	PrevMRR           *float64           `json:"prev_mrr,omitempty"`
+	Valuation         float64            `json:"valuation"`
+	Multiplier        float64            `json:"multiplier"`
+	BySource          map[string]float64 `json:"by_source"`
+	BySourcePercent   map[string]float64 `json:"by_source_percent"`
+	EntryCount        int                `json:"entry_count"`
+	SubscriptionMRR   float64            `json:"subscription_mrr"`
+	AdjustmentMRR     float64            `json:"adjustment_mrr"`
+	SubscriptionCount int                `json:"subscription_count"`
+	Movements         *movementData      `json:"movements,omitempty"`
+	UnitEconomics     *unitEconomicsData `json:"unit_economics,omitempty"`
 }
 
 func runReport(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	if cmd.Flags().Changed("from") || cmd.Flags().Changed("to") || cmd.Flags().Changed("period") {
+		if reportMonth != "" || reportAll || reportQuiet {
+			return fmt.Errorf("--from, --to and --period can't be combined with --month, --all-workspaces or --quiet")
+		}
+		return runSeriesReport(store, reportFrom, reportTo, reportPeriod)
+	}
+
 	month := reportMonth
 	if month == "" {
 		month = time.Now().Format("2006-01")
 	}
 
-	report, err := db.GetMonthlyReport(month)
+	if reportAll {
+		return runPortfolioReport(cmd, month)
+	}
+
+	data, report, err := buildReport(store, month, reportMultiplier)
 	if err != nil {
 		return err
 	}
 
-	mrr := float64(report.RecurringRevenue) / 100.0
-	arr := mrr * 12
-	valuation := arr * reportMultiplier
-
 	// Quiet mode - just output MRR number
 	if reportQuiet {
-		fmt.Printf("%.2f\n", mrr)
+		fmt.Printf("%.2f\n", data.MRR)
 		return nil
 	}
 
-	// Build report data
+	if reportJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	return printReport(data, report)
+}
+
+// buildReport computes the report for month from s, as printed by mrr
+// report and served by the API
+func buildReport(s db.Store, month string, multiplier float64) (reportData, *db.MonthlyReport, error) {
+	report, err := db.GetMonthlyReport(s, month)
+	if err != nil {
+		return reportData{}, nil, err
+	}
+
+	mrr := float64(report.RecurringRevenue) / 100.0
+	arr := mrr * 12
+	valuation := arr * multiplier
+
 	data := reportData{
-		Month:           month,
-		MRR:             mrr,
-		ARR:             arr,
-		OneTimeRevenue:  float64(report.OneTimeRevenue) / 100.0,
-		TotalRevenue:    float64(report.TotalRevenue) / 100.0,
-		Valuation:       valuation,
-		Multiplier:      reportMultiplier,
-		BySource:        make(map[string]float64),
-		BySourcePercent: make(map[string]float64),
-		EntryCount:      report.EntryCount,
+		Month:             month,
+		Currency:          report.Currency,
+		MRR:               mrr,
+		ARR:               arr,
+		OneTimeRevenue:    float64(report.OneTimeRevenue) / 100.0,
+		TotalRevenue:      float64(report.TotalRevenue) / 100.0,
+		Valuation:         valuation,
+		Multiplier:        multiplier,
+		BySource:          make(map[string]float64),
+		BySourcePercent:   make(map[string]float64),
+		EntryCount:        report.EntryCount,
+		SubscriptionMRR:   float64(report.SubscriptionMRR) / 100.0,
+		AdjustmentMRR:     float64(report.AdjustmentMRR) / 100.0,
+		SubscriptionCount: report.SubscriptionCount,
 	}
 
 	for source, amount := range report.BySource {
@@ -102,7 +158,7 @@ func runReport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Growth rate calculation
-	prevMRR, err := db.GetPreviousMonthMRR(month)
+	prevMRR, err := db.GetPreviousMonthMRR(s, month)
 	if err == nil && prevMRR > 0 {
 		growthRate := float64(report.RecurringRevenue-prevMRR) / float64(prevMRR) * 100
 		data.GrowthRate = &growthRate
@@ -110,13 +166,17 @@ func runReport(cmd *cobra.Command, args []string) error {
 		data.PrevMRR = &prevMRRFloat
 	}
 
-	if reportJSON {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(data)
+	// MRR bridge
+	if movement, err := db.GetMRRMovement(s, month); err == nil {
+		data.Movements = newMovementData(movement, s.ReportingCurrency())
 	}
 
-	return printReport(data, report)
+	// Unit economics, if there are paying customers to compute them for
+	if economics, err := buildUnitEconomics(s, month, defaultChurnMonths); err == nil && economics.PayingCustomers > 0 {
+		data.UnitEconomics = &economics
+	}
+
+	return data, report, nil
 }
 
 func printReport(data reportData, report *db.MonthlyReport) error {
@@ -137,14 +197,21 @@ func printReport(data reportData, report *db.MonthlyReport) error {
 	fmt.Println("  " + strings.Repeat("─", 35))
 	fmt.Println()
 
-	if report.EntryCount == 0 {
-		fmt.Printf("  %s No entries for this month.\n\n", yellow("⚠"))
+	if report.EntryCount == 0 && report.SubscriptionCount == 0 {
+		fmt.Printf("  %s No entries or active subscriptions for this month.\n\n", yellow("⚠"))
 		return nil
 	}
 
 	// Main metrics
-	fmt.Printf("  %s        %s\n", bold("MRR:"), green(models.FormatAmount(int64(data.MRR*100), "USD")))
-	fmt.Printf("  %s        %s\n", bold("ARR:"), green(models.FormatAmount(int64(data.ARR*100), "USD")))
+	fmt.Printf("  %s        %s\n", bold("MRR:"), green(models.FormatAmount(int64(data.MRR*100), data.Currency)))
+	fmt.Printf("  %s        %s\n", bold("ARR:"), green(models.FormatAmount(int64(data.ARR*100), data.Currency)))
+	if data.SubscriptionCount > 0 && data.AdjustmentMRR != 0 {
+		fmt.Printf("              %s from %d subscriptions, %s adjustments\n",
+			models.FormatAmount(int64(data.SubscriptionMRR*100), data.Currency),
+			data.SubscriptionCount,
+			models.FormatAmount(int64(data.AdjustmentMRR*100), data.Currency),
+		)
+	}
 
 	// Growth rate
 	if data.GrowthRate != nil {
@@ -159,12 +226,30 @@ func printReport(data reportData, report *db.MonthlyReport) error {
 	}
 
 	// Valuation
-	fmt.Printf("  %s  %s (at %.0fx ARR)\n", bold("Valuation:"), cyan(models.FormatAmount(int64(data.Valuation*100), "USD")), data.Multiplier)
+	fmt.Printf("  %s  %s (at %.0fx ARR)\n", bold("Valuation:"), cyan(models.FormatAmount(int64(data.Valuation*100), data.Currency)), data.Multiplier)
 	fmt.Println()
 
+	// MRR movements if anything moved
+	if m := data.Movements; m != nil && (m.New != 0 || m.Expansion != 0 || m.Reactivation != 0 || m.Contraction != 0 || m.Churn != 0) {
+		fmt.Printf("  %s\n", bold("Movements:"))
+		fmt.Printf("    New:          %s\n", green(models.FormatAmount(int64(m.New*100), data.Currency)))
+		fmt.Printf("    Expansion:    %s\n", green(models.FormatAmount(int64(m.Expansion*100), data.Currency)))
+		fmt.Printf("    Reactivation: %s\n", green(models.FormatAmount(int64(m.Reactivation*100), data.Currency)))
+		fmt.Printf("    Contraction:  %s\n", red(models.FormatAmount(int64(-m.Contraction*100), data.Currency)))
+		fmt.Printf("    Churn:        %s\n", red(models.FormatAmount(int64(-m.Churn*100), data.Currency)))
+		fmt.Println()
+	}
+
+	// Unit economics if there are subscription customers
+	if data.UnitEconomics != nil {
+		fmt.Printf("  %s\n", bold("Unit Economics:"))
+		printUnitEconomics(*data.UnitEconomics)
+		fmt.Println()
+	}
+
 	// One-time revenue if exists
 	if data.OneTimeRevenue > 0 {
-		fmt.Printf("  %s %s\n", bold("One-time:"), yellow(models.FormatAmount(int64(data.OneTimeRevenue*100), "USD")))
+		fmt.Printf("  %s %s\n", bold("One-time:"), yellow(models.FormatAmount(int64(data.OneTimeRevenue*100), data.Currency)))
 		fmt.Println()
 	}
 
@@ -192,7 +277,7 @@ func printReport(data reportData, report *db.MonthlyReport) error {
 
 			table.Rich([]string{
 				"    " + source + ":",
-				models.FormatAmount(int64(amount*100), "USD"),
+				models.FormatAmount(int64(amount*100), data.Currency),
 				fmt.Sprintf("(%.1f%%)", pct),
 			}, []tablewriter.Colors{
 				{tablewriter.FgMagentaColor},
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -9,6 +10,17 @@ import (
 	"github.com/indiekitai/mrr-cli/db"
 )
 
+type storeKey struct{}
+
+type sessionKey struct{}
+
+// session holds the storage backend a command reads and writes. It is
+// opened from --db, MRR_DB or the workspace unless injected with WithStore.
+type session struct {
+	store db.Store
+	owned bool // Opened by mrr, so closed after the command runs
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "mrr",
 	Short: "MRR Tracker - Track your Monthly Recurring Revenue",
@@ -20,19 +32,35 @@ VisiData-style TUI for interactive management.
 
 Examples:
   mrr add 29.99 --source stripe
+  mrr sub add 29 --customer acme
   mrr list --month 2024-01
   mrr report
   mrr forecast
   mrr export --json
-  mrr tui`,
+  mrr tui
+  mrr --workspace saas-b report`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return db.Init()
+		return openDatabase(cmd, true)
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
-		db.Close()
+		closeStore(cmd)
 	},
 }
 
+// WithStore returns a copy of ctx that makes commands run with
+// ExecuteContext use s instead of opening a SQLite database, for embedding
+// mrr-cli in other Go programs. The caller owns s and closes it.
+func WithStore(ctx context.Context, s db.Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, s)
+}
+
+// ExecuteContext runs the root command with ctx and args, or os.Args when
+// none are given, returning its error
+func ExecuteContext(ctx context.Context, args ...string) error {
+	rootCmd.SetArgs(args)
+	return rootCmd.ExecuteContext(ctx)
+}
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -41,7 +69,87 @@ func Execute() {
 	}
 }
 
+// openDatabase opens the selected database for cmd, optionally applying
+// pending migrations, and loads the workspace config. A store injected with
+// WithStore is used as is.
+func openDatabase(cmd *cobra.Command, migrate bool) error {
+	ctx := cmd.Root().Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	sess := &session{}
+	cmd.SetContext(context.WithValue(ctx, sessionKey{}, sess))
+
+	if s, ok := ctx.Value(storeKey{}).(db.Store); ok && s != nil {
+		sess.store = s
+		return nil
+	}
+
+	if dbPathFlag == "" && os.Getenv("MRR_DB") == "" {
+		if err := checkWorkspace(currentWorkspace()); err != nil {
+			return err
+		}
+	}
+
+	path, err := databasePath()
+	if err != nil {
+		return err
+	}
+
+	var sqlite *db.SQLiteStore
+	if migrate {
+		sqlite, err = db.NewSQLiteStore(path)
+	} else {
+		sqlite, err = db.OpenSQLiteStore(path)
+	}
+	if err != nil {
+		return err
+	}
+	sess.store = sqlite
+	sess.owned = true
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	sqlite.SetReportingCurrency(config.Currency)
+
+	return nil
+}
+
+// sessionFrom returns the session openDatabase started for cmd
+func sessionFrom(cmd *cobra.Command) *session {
+	if sess, ok := cmd.Context().Value(sessionKey{}).(*session); ok {
+		return sess
+	}
+	return &session{}
+}
+
+// storeFrom returns the store cmd reads and writes
+func storeFrom(cmd *cobra.Command) db.Store {
+	return sessionFrom(cmd).store
+}
+
+// storeInjected reports whether cmd runs against a store injected with
+// WithStore rather than a SQLite database it opened
+func storeInjected(cmd *cobra.Command) bool {
+	sess := sessionFrom(cmd)
+	return sess.store != nil && !sess.owned
+}
+
+// closeStore closes cmd's store unless it was injected
+func closeStore(cmd *cobra.Command) {
+	sess := sessionFrom(cmd)
+	if sess.store != nil && sess.owned {
+		sess.store.Close()
+		sess.store = nil
+	}
+}
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&dbPathFlag, "db", "", "Database file path (overrides MRR_DB and workspace)")
+	rootCmd.PersistentFlags().StringVarP(&workspaceFlag, "workspace", "w", "", "Workspace name (overrides MRR_WORKSPACE)")
+
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(editCmd)
@@ -54,4 +162,22 @@ func init() {
 	rootCmd.AddCommand(badgeCmd)
 	rootCmd.AddCommand(goalCmd)
 	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(subCmd)
+	rootCmd.AddCommand(movementsCmd)
+	rootCmd.AddCommand(customerCmd)
+	rootCmd.AddCommand(fxCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(workspaceCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(webhookCmd)
+	rootCmd.AddCommand(tokenCmd)
+	rootCmd.AddCommand(publishCmd)
+	rootCmd.AddCommand(privacyCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(cohortsCmd)
+	rootCmd.AddCommand(economicsCmd)
+	rootCmd.AddCommand(expenseCmd)
+	rootCmd.AddCommand(pnlCmd)
 }
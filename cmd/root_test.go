@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+func TestCommandsUseInjectedStore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MRR_DB", "")
+	t.Setenv("MRR_WORKSPACE", "")
+
+	s := db.NewMemoryStore()
+	ctx := WithStore(context.Background(), s)
+	for _, args := range [][]string{
+		{"sub", "add", "29", "--customer", "acme", "--start", "2026-01-15"},
+		{"add", "12.50", "--customer", "acme", "--type", "one-time", "--date", "2026-02-03"},
+	} {
+		if err := ExecuteContext(ctx, args...); err != nil {
+			t.Fatalf("mrr %v: %v", args, err)
+		}
+	}
+
+	customer, err := s.GetCustomerByName("acme")
+	if err != nil {
+		t.Fatalf("customer not added to the injected store: %v", err)
+	}
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].Amount != 2900 || subs[0].CustomerID != customer.ID {
+		t.Errorf("subscriptions = %+v, want one of 2900 for customer %d", subs, customer.ID)
+	}
+	entries, err := s.ListEntries("", "", "", customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Amount != 1250 {
+		t.Errorf("entries = %+v, want one of 1250", entries)
+	}
+
+	// Nothing may be written to the default database
+	files, err := os.ReadDir(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("commands wrote to %s: %v", home, files)
+	}
+}
+
+func TestSubAddRejectsNegativeAmounts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s := db.NewMemoryStore()
+	ctx := WithStore(context.Background(), s)
+	if err := ExecuteContext(ctx, "sub", "add", "--customer", "acme", "--", "-29"); err == nil {
+		t.Error("sub add -29 succeeded, want an error")
+	}
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 0 {
+		t.Errorf("got %d subscriptions, want none", len(subs))
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// seriesDefaultPeriods is how many periods a time series covers when --from
+// isn't given
+const seriesDefaultPeriods = 12
+
+type seriesPeriod struct {
+	Period            string   `json:"period"`
+	Start             string   `json:"start"`
+	End               string   `json:"end"`
+	MRR               float64  `json:"mrr"`
+	ARR               float64  `json:"arr"`
+	OneTimeRevenue    float64  `json:"one_time_revenue"`
+	EntryCount        int      `json:"entry_count"`
+	SubscriptionCount int      `json:"subscription_count"`
+	GrowthRate        *float64 `json:"growth_rate,omitempty"` // vs the previous period
+	QoQ               *float64 `json:"qoq,omitempty"`         // vs three months earlier
+	YoY               *float64 `json:"yoy,omitempty"`         // vs a year earlier
+}
+
+type seriesData struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Period   string         `json:"period"`
+	Currency string         `json:"currency"`
+	Periods  []seriesPeriod `json:"periods"`
+}
+
+// percentChange returns the change from prev to cur in percent, nil if
+// there was nothing to grow from
+func percentChange(cur, prev int64) *float64 {
+	if prev <= 0 {
+		return nil
+	}
+	change := float64(cur-prev) / float64(prev) * 100
+	return &change
+}
+
+// parseReportDate parses YYYY-MM-DD or YYYY-MM. A month stands for its
+// first day, or for its last day if end is set.
+func parseReportDate(s string, end bool) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format: %s (use YYYY-MM or YYYY-MM-DD)", s)
+	}
+	if end {
+		return t.AddDate(0, 1, -1), nil
+	}
+	return t, nil
+}
+
+// buildSeries reports on every period of the given granularity from the
+// one holding from through the one holding to
+func buildSeries(s db.Store, from, to time.Time, period string) (seriesData, error) {
+	reports, err := db.GetPeriodReports(s, from, to, period)
+	if err != nil {
+		return seriesData{}, err
+	}
+
+	data := seriesData{
+		Period:   period,
+		Currency: s.ReportingCurrency(),
+		Periods:  []seriesPeriod{},
+	}
+	for _, r := range reports {
+		mrr := float64(r.MRR) / 100.0
+		data.Periods = append(data.Periods, seriesPeriod{
+			Period:            r.Period,
+			Start:             r.Start.Format("2006-01-02"),
+			End:               r.End.Format("2006-01-02"),
+			MRR:               mrr,
+			ARR:               mrr * 12,
+			OneTimeRevenue:    float64(r.OneTimeRevenue) / 100.0,
+			EntryCount:        r.EntryCount,
+			SubscriptionCount: r.SubscriptionCount,
+			GrowthRate:        percentChange(r.MRR, r.PrevMRR),
+			QoQ:               percentChange(r.MRR, r.QuarterAgoMRR),
+			YoY:               percentChange(r.MRR, r.YearAgoMRR),
+		})
+	}
+	if len(reports) > 0 {
+		data.From = reports[0].Start.Format("2006-01-02")
+		data.To = reports[len(reports)-1].End.Format("2006-01-02")
+	}
+
+	return data, nil
+}
+
+// runSeriesReport reports MRR per period over a date range
+func runSeriesReport(s db.Store, fromStr, toStr, period string) error {
+	if !db.IsValidPeriod(period) {
+		return fmt.Errorf("invalid period: %s (valid: %v)", period, db.ValidPeriods)
+	}
+
+	to := time.Now()
+	if toStr != "" {
+		t, err := parseReportDate(toStr, true)
+		if err != nil {
+			return err
+		}
+		to = t
+	}
+
+	var from time.Time
+	if fromStr != "" {
+		t, err := parseReportDate(fromStr, false)
+		if err != nil {
+			return err
+		}
+		from = t
+	} else {
+		from = db.PeriodStart(to, period)
+		for i := 1; i < seriesDefaultPeriods; i++ {
+			from = db.PeriodStart(from.AddDate(0, 0, -1), period)
+		}
+	}
+
+	data, err := buildSeries(s, from, to, period)
+	if err != nil {
+		return err
+	}
+
+	if reportJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	printSeries(data)
+	return nil
+}
+
+func printSeries(data seriesData) {
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+
+	fmt.Println()
+	fmt.Printf("  %s\n", cyan(fmt.Sprintf("MRR by %s: %s – %s", data.Period, data.From, data.To)))
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Period", "MRR", "One-time", "Growth", "QoQ", "YoY", "Subs", "Entries"})
+	table.SetBorder(false)
+	headerColors := make([]tablewriter.Colors, 8)
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, p := range data.Periods {
+		table.Rich([]string{
+			p.Period,
+			models.FormatAmount(int64(math.Round(p.MRR*100)), data.Currency),
+			models.FormatAmount(int64(math.Round(p.OneTimeRevenue*100)), data.Currency),
+			formatGrowth(p.GrowthRate),
+			formatGrowth(p.QoQ),
+			formatGrowth(p.YoY),
+			strconv.Itoa(p.SubscriptionCount),
+			strconv.Itoa(p.EntryCount),
+		}, []tablewriter.Colors{
+			{tablewriter.FgBlueColor},
+			{tablewriter.FgGreenColor},
+			{tablewriter.FgYellowColor},
+			growthColor(p.GrowthRate),
+			growthColor(p.QoQ),
+			growthColor(p.YoY),
+			{},
+			{},
+		})
+	}
+
+	table.Render()
+	fmt.Println()
+}
+
+// formatGrowth formats a signed percentage change, or a dash if there is none
+func formatGrowth(rate *float64) string {
+	if rate == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%+.1f%%", *rate)
+}
+
+// growthColor colors growth green and decline red
+func growthColor(rate *float64) tablewriter.Colors {
+	switch {
+	case rate == nil || *rate == 0:
+		return tablewriter.Colors{}
+	case *rate > 0:
+		return tablewriter.Colors{tablewriter.FgGreenColor}
+	default:
+		return tablewriter.Colors{tablewriter.FgRedColor}
+	}
+}
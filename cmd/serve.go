@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"math"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -32,6 +34,20 @@ The dashboard shows:
 - Goal progress bar (if set)
 - Last updated timestamp
 
+Webhooks from Stripe, Paddle and Gumroad are accepted at /webhooks/<provider>
+for every provider with a secret (see 'mrr webhook'), keeping the dashboard
+current as payments and subscription changes come in.
+
+A REST API for entries, reports, forecasts and the goal is served at
+/api/v1 for requests with a token from 'mrr token create'.
+
+Prometheus metrics (MRR, ARR, growth, per-source MRR, goal progress and
+entry counts) are served at /metrics. In public mode they need a token, as
+they aren't subject to the privacy settings.
+
+In public mode the dashboard and /api/data publish only what the privacy
+settings allow (see 'mrr privacy'). Restart the server after changing them.
+
 Examples:
   mrr serve                   # Serve on port 8080
   mrr serve --port 3000       # Custom port
@@ -44,19 +60,50 @@ func init() {
 	serveCmd.Flags().BoolVar(&servePublic, "public", false, "Public mode (hides entry details)")
 }
 
+// dashboardData is the dashboard's content. Amounts the privacy settings
+// don't allow to be published are nil.
 type dashboardData struct {
-	CurrentMRR    float64            `json:"current_mrr"`
-	ARR           float64            `json:"arr"`
-	GrowthRate    *float64           `json:"growth_rate,omitempty"`
-	MonthlyTrend  []monthlyDataPoint `json:"monthly_trend"`
-	Goal          *goalData          `json:"goal,omitempty"`
-	IsPublic      bool               `json:"is_public"`
-	LastUpdated   string             `json:"last_updated"`
+	Month        string             `json:"month"`
+	Currency     string             `json:"currency"`
+	CurrentMRR   *float64           `json:"current_mrr,omitempty"`
+	MRRRange     *amountRange       `json:"mrr_range,omitempty"` // Instead of the MRR when bucketed
+	ARR          *float64           `json:"arr,omitempty"`
+	ARRRange     *amountRange       `json:"arr_range,omitempty"`
+	GrowthRate   *float64           `json:"growth_rate,omitempty"`
+	MonthlyTrend []monthlyDataPoint `json:"monthly_trend"`
+	Sources      []sourceDataPoint  `json:"sources,omitempty"`
+	Retention    []retentionPeriod  `json:"retention,omitempty"`
+	Goal         *goalData          `json:"goal,omitempty"`
+	Movements    *movementData      `json:"movements,omitempty"`
+	Privacy      string             `json:"privacy,omitempty"` // Amount privacy level, unless exact
+	AsOf         string             `json:"as_of,omitempty"`   // Day the data is from, when published with a delay
+	IsPublic     bool               `json:"is_public"`
+	LastUpdated  string             `json:"last_updated"`
+
+	report     *db.MonthlyReport // The month's report the figures come from
+	historyURL string            // Link to the month history, on published pages
 }
 
 type monthlyDataPoint struct {
-	Month string  `json:"month"`
-	MRR   float64 `json:"mrr"`
+	Month  string       `json:"month"`
+	MRR    *float64     `json:"mrr,omitempty"`
+	Range  *amountRange `json:"range,omitempty"`
+	Growth *float64     `json:"growth,omitempty"` // Percent change from the month before
+
+	height float64 // Chart bar height relative to the highest month, 0 to 1
+}
+
+// retentionPeriod is the churn and retention over the months up to the
+// dashboard's month
+type retentionPeriod struct {
+	Months int `json:"months"`
+	retentionRates
+}
+
+type sourceDataPoint struct {
+	Source string   `json:"source"`
+	MRR    *float64 `json:"mrr,omitempty"`
+	Share  float64  `json:"share"` // Percent of MRR
 }
 
 type goalData struct {
@@ -66,6 +113,8 @@ type goalData struct {
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
 	green := color.New(color.FgGreen).SprintFunc()
 	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
 
@@ -73,59 +122,184 @@ func runServe(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %s MRR Dashboard\n", cyan("📊"))
 	fmt.Println("  " + strings.Repeat("─", 35))
 	fmt.Printf("  %s http://localhost:%d\n", green("→"), servePort)
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	privacy := PrivacyConfig{}
+	if config.Privacy != nil {
+		privacy = *config.Privacy
+	}
 	if servePublic {
 		fmt.Println("  Mode: Public (hiding entry details)")
+		fmt.Printf("  Privacy: %s amounts", privacy.amounts())
+		if privacy.HideSources {
+			fmt.Print(", sources hidden")
+		}
+		if privacy.DelayDays > 0 {
+			fmt.Printf(", %d days delay", privacy.DelayDays)
+		}
+		fmt.Println()
+	}
+	secrets := webhookSecrets(config)
+	for _, provider := range webhookProviders {
+		if secrets.get(provider) != "" {
+			fmt.Printf("  Webhooks: /webhooks/%s\n", provider)
+		}
 	}
+	fmt.Println("  API: /api/v1 (tokens: mrr token create)")
+	if servePublic {
+		fmt.Println("  Metrics: /metrics (tokens: mrr token create)")
+	} else {
+		fmt.Println("  Metrics: /metrics")
+	}
+
 	fmt.Println()
 	fmt.Println("  Press Ctrl+C to stop")
 	fmt.Println()
 
-	http.HandleFunc("/", handleDashboard)
-	http.HandleFunc("/api/data", handleAPIData)
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/", NewWebhookHandler(store, secrets, os.Stdout))
+	mux.Handle("/api/v1/", NewAPIHandler(store))
+	if servePublic {
+		// Metrics are exact, so the public can't scrape them
+		mux.Handle("/metrics", (&apiServer{store: store}).authenticate(NewMetricsHandler(store, true)))
+	} else {
+		mux.Handle("/metrics", NewMetricsHandler(store, false))
+	}
+	if servePublic {
+		mux.Handle("/", NewPublicDashboardHandler(store, privacy))
+	} else {
+		mux.Handle("/", NewDashboardHandler(store, false))
+	}
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", servePort), nil)
+	return http.ListenAndServe(fmt.Sprintf(":%d", servePort), mux)
 }
 
-func getDashboardData() (*dashboardData, error) {
-	currentMonth := time.Now().Format("2006-01")
+// dashboardServer serves the dashboard and its JSON API from a store
+type dashboardServer struct {
+	store   db.Store
+	public  bool
+	privacy PrivacyConfig // Applied in public mode
+}
 
-	// Get current MRR
-	report, err := db.GetMonthlyReport(currentMonth)
+// NewDashboardHandler returns the dashboard's HTTP handler backed by s, so
+// it can be mounted in other Go programs
+func NewDashboardHandler(s db.Store, public bool) http.Handler {
+	return newDashboardHandler(&dashboardServer{store: s, public: public})
+}
+
+// NewPublicDashboardHandler returns the public dashboard's HTTP handler,
+// publishing only what privacy allows
+func NewPublicDashboardHandler(s db.Store, privacy PrivacyConfig) http.Handler {
+	return newDashboardHandler(&dashboardServer{store: s, public: true, privacy: privacy})
+}
+
+func newDashboardHandler(srv *dashboardServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", srv.handleDashboard)
+	mux.HandleFunc("/api/data", srv.handleAPIData)
+
+	return mux
+}
+
+func getDashboardData(s db.Store, public bool) (*dashboardData, error) {
+	return getMonthDashboardData(s, public, time.Now().Format("2006-01"))
+}
+
+// getMonthDashboardData builds the dashboard as of a YYYY-MM month, with the
+// trend covering the six months up to it
+func getMonthDashboardData(s db.Store, public bool, month string) (*dashboardData, error) {
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format, use YYYY-MM: %s", month)
+	}
+
+	// Get the month's MRR
+	report, err := db.GetMonthlyReport(s, month)
 	if err != nil {
 		return nil, err
 	}
 
 	currentMRR := float64(report.RecurringRevenue) / 100.0
+	arr := currentMRR * 12
 
 	data := &dashboardData{
-		CurrentMRR:  currentMRR,
-		ARR:         currentMRR * 12,
-		IsPublic:    servePublic,
+		Month:       month,
+		Currency:    report.Currency,
+		CurrentMRR:  &currentMRR,
+		ARR:         &arr,
+		IsPublic:    public,
 		LastUpdated: time.Now().Format(time.RFC3339),
+		report:      report,
 	}
 
 	// Growth rate
-	prevMRR, err := db.GetPreviousMonthMRR(currentMonth)
+	prevMRR, err := db.GetPreviousMonthMRR(s, month)
 	if err == nil && prevMRR > 0 {
 		growthRate := float64(report.RecurringRevenue-prevMRR) / float64(prevMRR) * 100
 		data.GrowthRate = &growthRate
 	}
 
+	// MRR by source, largest first
+	for _, source := range sortedKeys(report.MRRBySource) {
+		amount := float64(report.MRRBySource[source]) / 100.0
+		if amount == 0 {
+			continue
+		}
+		share := 0.0
+		if report.RecurringRevenue != 0 {
+			share = float64(report.MRRBySource[source]) / float64(report.RecurringRevenue) * 100
+		}
+		data.Sources = append(data.Sources, sourceDataPoint{Source: source, MRR: &amount, Share: share})
+	}
+	sort.SliceStable(data.Sources, func(i, j int) bool {
+		return data.Sources[i].Share > data.Sources[j].Share
+	})
+
+	// Churn and retention over the month, quarter and year, if anyone was
+	// paying at their start
+	for _, months := range []int{1, 3, 12} {
+		r, err := trailingRetention(s, month, months)
+		if err != nil {
+			return nil, err
+		}
+		if r.StartCustomers > 0 {
+			data.Retention = append(data.Retention, retentionPeriod{Months: months, retentionRates: newRetentionRates(r)})
+		}
+	}
+
+	// MRR bridge for the month
+	if movement, err := db.GetMRRMovement(s, month); err == nil {
+		data.Movements = newMovementData(movement, s.ReportingCurrency())
+	}
+
 	// Get last 6 months of data
 	data.MonthlyTrend = []monthlyDataPoint{}
+	maxMRR := 0.0
 	for i := 5; i >= 0; i-- {
-		monthDate := time.Now().AddDate(0, -i, 0)
-		monthStr := monthDate.Format("2006-01")
+		monthStr := monthStart.AddDate(0, -i, 0).Format("2006-01")
 
-		monthReport, err := db.GetMonthlyReport(monthStr)
+		monthReport, err := db.GetMonthlyReport(s, monthStr)
 		if err != nil {
 			continue
 		}
 
-		data.MonthlyTrend = append(data.MonthlyTrend, monthlyDataPoint{
-			Month: monthStr,
-			MRR:   float64(monthReport.RecurringRevenue) / 100.0,
-		})
+		mrr := float64(monthReport.RecurringRevenue) / 100.0
+		point := monthlyDataPoint{Month: monthStr, MRR: &mrr}
+		if n := len(data.MonthlyTrend); n > 0 && *data.MonthlyTrend[n-1].MRR > 0 {
+			growth := (mrr - *data.MonthlyTrend[n-1].MRR) / *data.MonthlyTrend[n-1].MRR * 100
+			point.Growth = &growth
+		}
+		if mrr > maxMRR {
+			maxMRR = mrr
+		}
+		data.MonthlyTrend = append(data.MonthlyTrend, point)
+	}
+	for i := range data.MonthlyTrend {
+		if maxMRR > 0 {
+			data.MonthlyTrend[i].height = *data.MonthlyTrend[i].MRR / maxMRR
+		}
 	}
 
 	// Goal
@@ -146,8 +320,16 @@ func getDashboardData() (*dashboardData, error) {
 	return data, nil
 }
 
-func handleAPIData(w http.ResponseWriter, r *http.Request) {
-	data, err := getDashboardData()
+// data builds the dashboard, applying the privacy settings in public mode
+func (srv *dashboardServer) data() (*dashboardData, error) {
+	if srv.public {
+		return publicDashboardData(srv.store, srv.privacy, "")
+	}
+	return getDashboardData(srv.store, false)
+}
+
+func (srv *dashboardServer) handleAPIData(w http.ResponseWriter, r *http.Request) {
+	data, err := srv.data()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -158,25 +340,35 @@ func handleAPIData(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(data)
 }
 
-func handleDashboard(w http.ResponseWriter, r *http.Request) {
-	data, err := getDashboardData()
+func (srv *dashboardServer) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	data, err := srv.data()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	fmt.Fprint(w, generateHTML(data))
+	fmt.Fprint(w, generateHTML(srv.store, data))
 }
 
-func generateHTML(data *dashboardData) string {
+func generateHTML(s db.Store, data *dashboardData) string {
 	// Format numbers
-	mrrFormatted := models.FormatAmount(int64(data.CurrentMRR*100), "USD")
-	arrFormatted := models.FormatAmount(int64(data.ARR*100), "USD")
+	mrrLabel := "Monthly Recurring Revenue"
+	mrrFormatted := data.formatAmount(data.CurrentMRR, data.MRRRange)
+	var arrHTML string
+	if arrFormatted := data.formatAmount(data.ARR, data.ARRRange); arrFormatted != "" {
+		arrHTML = fmt.Sprintf(`<div class="arr">%s ARR</div>`, html.EscapeString(arrFormatted))
+	}
 
-	// Growth badge
+	// Growth badge, or the growth itself when amounts are hidden
 	var growthBadge string
-	if data.GrowthRate != nil {
+	if mrrFormatted == "" {
+		mrrLabel = "MRR Growth"
+		mrrFormatted = "—"
+		if data.GrowthRate != nil {
+			mrrFormatted = fmt.Sprintf("%+.1f%%", *data.GrowthRate)
+		}
+	} else if data.GrowthRate != nil {
 		if *data.GrowthRate > 0 {
 			growthBadge = fmt.Sprintf(`<span class="badge green">↑ %.1f%%</span>`, *data.GrowthRate)
 		} else if *data.GrowthRate < 0 {
@@ -189,7 +381,7 @@ func generateHTML(data *dashboardData) string {
 	// Goal section
 	var goalHTML string
 	if data.Goal != nil {
-		goalFormatted := models.FormatAmount(int64(data.Goal.Amount*100), "USD")
+		goalFormatted := models.FormatAmount(int64(data.Goal.Amount*100), data.Currency)
 		deadlineStr := ""
 		if data.Goal.Deadline != "" {
 			deadline, _ := time.Parse("2006-01", data.Goal.Deadline)
@@ -205,54 +397,114 @@ func generateHTML(data *dashboardData) string {
 		</div>`, html.EscapeString(goalFormatted), html.EscapeString(deadlineStr), data.Goal.Progress, data.Goal.Progress)
 	}
 
-	// Chart data
-	chartLabels := []string{}
-	chartData := []string{}
-	maxMRR := 0.0
-	for _, point := range data.MonthlyTrend {
-		t, _ := time.Parse("2006-01", point.Month)
-		chartLabels = append(chartLabels, fmt.Sprintf(`"%s"`, t.Format("Jan")))
-		chartData = append(chartData, fmt.Sprintf("%.2f", point.MRR))
-		if point.MRR > maxMRR {
-			maxMRR = point.MRR
-		}
-	}
-
 	// Chart bars (simple CSS bars)
 	var chartBars string
 	for _, point := range data.MonthlyTrend {
 		t, _ := time.Parse("2006-01", point.Month)
-		height := 0.0
-		if maxMRR > 0 {
-			height = point.MRR / maxMRR * 100
+		title := data.formatAmount(point.MRR, point.Range)
+		if title == "" && point.Growth != nil {
+			title = fmt.Sprintf("%+.1f%%", *point.Growth)
 		}
 		label := t.Format("Jan")
 		chartBars += fmt.Sprintf(`
 			<div class="chart-bar-wrapper">
 				<div class="chart-bar" style="height: %.1f%%" title="%s"></div>
 				<div class="chart-label">%s</div>
-			</div>`, height, models.FormatAmount(int64(point.MRR*100), "USD"), label)
+			</div>`, point.height*100, html.EscapeString(title), label)
+	}
+
+	// MRR by source
+	var sourcesHTML string
+	if len(data.Sources) > 0 {
+		sourceRows := ""
+		for _, source := range data.Sources {
+			amount := ""
+			if formatted := data.formatAmount(source.MRR, nil); formatted != "" {
+				amount = " · " + formatted
+			}
+			sourceRows += fmt.Sprintf(`
+				<div class="source-row">
+					<div class="source-name">%s<span>%.0f%%%s</span></div>
+					<div class="progress-bar"><div class="progress-fill" style="width: %.1f%%"></div></div>
+				</div>`, html.EscapeString(source.Source), source.Share, html.EscapeString(amount), math.Max(0, math.Min(100, source.Share)))
+		}
+		sourcesHTML = fmt.Sprintf(`
+		<div class="section">
+			<h3>MRR by Source</h3>%s
+		</div>`, sourceRows)
+	}
+
+	// Churn and retention
+	var retentionHTML string
+	if len(data.Retention) > 0 {
+		retentionRows := ""
+		for _, period := range data.Retention {
+			label := "This month"
+			if period.Months > 1 {
+				label = fmt.Sprintf("Last %d months", period.Months)
+			}
+			retentionRows += fmt.Sprintf(`
+				<tr>
+					<td>%s</td>
+					<td>%s</td>
+					<td>%s</td>
+					<td>%s</td>
+					<td class="amount">%s</td>
+				</tr>`,
+				label,
+				formatRate(period.LogoChurnRate),
+				formatRate(period.GrossMRRChurnRate),
+				formatRate(period.GRR),
+				formatRate(period.NRR),
+			)
+		}
+		retentionHTML = fmt.Sprintf(`
+		<div class="section">
+			<h3>Retention</h3>
+			<table class="entries-table">
+				<thead>
+					<tr><th></th><th>Logo churn</th><th>MRR churn</th><th>GRR</th><th>NRR</th></tr>
+				</thead>
+				<tbody>%s</tbody>
+			</table>
+		</div>`, retentionRows)
 	}
 
 	// Parse last updated time
 	lastUpdated, _ := time.Parse(time.RFC3339, data.LastUpdated)
 	lastUpdatedStr := lastUpdated.Format("Jan 2, 2006 at 3:04 PM")
 
+	if data.AsOf != "" {
+		asOf, _ := time.Parse("2006-01-02", data.AsOf)
+		lastUpdatedStr += " (data as of " + asOf.Format("Jan 2, 2006") + ")"
+	}
+
+	// Past months are labelled with the month they show
+	if data.Month != "" && data.Month != time.Now().Format("2006-01") {
+		month, _ := time.Parse("2006-01", data.Month)
+		mrrLabel += " · " + month.Format("January 2006")
+	}
+
+	var historyLink string
+	if data.historyURL != "" {
+		historyLink = fmt.Sprintf(`<a href="%s">Monthly history</a><br>`, html.EscapeString(data.historyURL))
+	}
+
 	// Build recent entries section (only for non-public mode)
 	var recentEntriesHTML string
 	if !data.IsPublic {
-		entries, err := db.ListEntries("", "", "")
+		entries, err := s.ListEntries("", "", "", 0)
 		if err == nil && len(entries) > 0 {
 			// Sort by date descending and take first 5
 			sort.Slice(entries, func(i, j int) bool {
 				return entries[i].Date.After(entries[j].Date)
 			})
-			
+
 			limit := 5
 			if len(entries) < limit {
 				limit = len(entries)
 			}
-			
+
 			entriesRows := ""
 			for _, e := range entries[:limit] {
 				entriesRows += fmt.Sprintf(`
@@ -261,14 +513,14 @@ func generateHTML(data *dashboardData) string {
 					<td>%s</td>
 					<td class="amount">%s</td>
 					<td>%s</td>
-				</tr>`, 
+				</tr>`,
 					e.Date.Format("Jan 2"),
 					html.EscapeString(e.Source),
-					models.FormatAmount(e.Amount, "USD"),
+					models.FormatAmount(e.Amount, e.Currency),
 					html.EscapeString(e.Type),
 				)
 			}
-			
+
 			recentEntriesHTML = fmt.Sprintf(`
 			<div class="section">
 				<h3>Recent Entries</h3>
@@ -424,6 +676,18 @@ func generateHTML(data *dashboardData) string {
 			color: #666;
 			margin-top: 10px;
 		}
+		.source-row {
+			margin-bottom: 12px;
+		}
+		.source-name {
+			display: flex;
+			justify-content: space-between;
+			font-size: 14px;
+			margin-bottom: 6px;
+		}
+		.source-name span {
+			color: #666;
+		}
 		.entries-table {
 			width: 100%%;
 			border-collapse: collapse;
@@ -470,10 +734,10 @@ func generateHTML(data *dashboardData) string {
 	<div class="container">
 		<div class="card">
 			<div class="header">
-				<div class="mrr-label">Monthly Recurring Revenue</div>
+				<div class="mrr-label">%s</div>
 				<div class="mrr-value">%s</div>
 				%s
-				<div class="arr">%s ARR</div>
+				%s
 			</div>
 
 			<div class="section">
@@ -487,7 +751,12 @@ func generateHTML(data *dashboardData) string {
 
 			%s
 
+			%s
+
+			%s
+
 			<div class="footer">
+				%s
 				Last updated: %s<br>
 				Powered by <a href="https://github.com/indiekitai/mrr-cli" target="_blank">mrr-cli</a>
 			</div>
@@ -495,12 +764,31 @@ func generateHTML(data *dashboardData) string {
 	</div>
 </body>
 </html>`,
+		html.EscapeString(mrrLabel),
 		html.EscapeString(mrrFormatted),
 		growthBadge,
-		html.EscapeString(arrFormatted),
+		arrHTML,
 		chartBars,
+		sourcesHTML,
+		retentionHTML,
 		goalHTML,
 		recentEntriesHTML,
+		historyLink,
 		html.EscapeString(lastUpdatedStr),
 	)
 }
+
+// formatAmount formats an amount on the dashboard as its privacy level
+// allows: the range it falls in when bucketed, rounded or exact. It returns
+// "" for hidden amounts.
+func (data *dashboardData) formatAmount(amount *float64, r *amountRange) string {
+	switch {
+	case r != nil:
+		return r.Label
+	case amount == nil:
+		return ""
+	case data.Privacy == AmountsRounded:
+		return "≈ " + models.FormatCompactAmount(int64(*amount*100), data.Currency)
+	}
+	return models.FormatAmount(int64(*amount*100), data.Currency)
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+var (
+	subCustomer string
+	subInterval string
+	subSource   string
+	subNote     string
+	subStart    string
+	subCancelAt string
+	subCurrency string
+	subActive   bool
+	subJSON     bool
+)
+
+var subCmd = &cobra.Command{
+	Use:   "sub",
+	Short: "Manage subscriptions",
+	Long: `Manage customer subscriptions. Active subscriptions drive MRR in
+reports, forecasts, goals, badges and the dashboard, so you don't need to
+re-enter recurring revenue every month. Recurring entries added with
+'mrr add' still count as manual MRR adjustments for the month they're dated.
+
+Examples:
+  mrr sub add 29 --customer acme
+  mrr sub add 290 --customer globex --interval year --source stripe
+  mrr sub cancel 3
+  mrr sub list --active`,
+}
+
+var subAddCmd = &cobra.Command{
+	Use:   "add <amount>",
+	Short: "Add a subscription",
+	Long: `Add a subscription. Amount is the plan price in dollars per billing interval.
+Annual plans contribute amount/12 to MRR.
+
+--customer takes a customer's name or ID. A name that isn't a customer yet
+is added as one, so 'mrr customer show' includes the subscription.
+
+Examples:
+  mrr sub add 29 --customer acme
+  mrr sub add 290 --customer globex --interval year
+  mrr sub add 99 --customer initech --source stripe --start 2026-01-15`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSubAdd,
+}
+
+var subCancelCmd = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel a subscription",
+	Long: `Cancel a subscription. It stops contributing to MRR from the cancel date.
+
+Examples:
+  mrr sub cancel 3
+  mrr sub cancel 3 --date 2026-02-28`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSubCancel,
+}
+
+var subListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List subscriptions",
+	Long: `List subscriptions.
+
+Examples:
+  mrr sub list
+  mrr sub list --active
+  mrr sub list --json`,
+	RunE: runSubList,
+}
+
+func init() {
+	subAddCmd.Flags().StringVarP(&subCustomer, "customer", "c", "", "Customer name or ID (required)")
+	subAddCmd.Flags().StringVarP(&subInterval, "interval", "i", "month", "Billing interval (month, year)")
+	subAddCmd.Flags().StringVarP(&subSource, "source", "s", "manual", "Revenue source (stripe, gumroad, paddle, lemonsqueezy, manual)")
+	subAddCmd.Flags().StringVar(&subCurrency, "currency", "", "ISO currency code (defaults to reporting currency)")
+	subAddCmd.Flags().StringVarP(&subNote, "note", "n", "", "Note for this subscription")
+	subAddCmd.Flags().StringVarP(&subStart, "start", "d", "", "Start date (YYYY-MM-DD, defaults to today)")
+	subAddCmd.MarkFlagRequired("customer")
+
+	subCancelCmd.Flags().StringVarP(&subCancelAt, "date", "d", "", "Cancel date (YYYY-MM-DD, defaults to today)")
+
+	subListCmd.Flags().BoolVarP(&subActive, "active", "a", false, "Only show active subscriptions")
+	subListCmd.Flags().BoolVarP(&subJSON, "json", "j", false, "Output as JSON")
+
+	subCmd.AddCommand(subAddCmd)
+	subCmd.AddCommand(subCancelCmd)
+	subCmd.AddCommand(subListCmd)
+}
+
+type subEntry struct {
+	ID         int64   `json:"id"`
+	Customer   string  `json:"customer"`
+	CustomerID int64   `json:"customer_id,omitempty"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Interval   string  `json:"interval"`
+	MRR        float64 `json:"mrr"`
+	Source     string  `json:"source"`
+	StartDate  string  `json:"start_date"`
+	CancelDate string  `json:"cancel_date,omitempty"`
+	Note       string  `json:"note,omitempty"`
+}
+
+type subListOutput struct {
+	Subscriptions []subEntry `json:"subscriptions"`
+	MRR           float64    `json:"mrr"`
+	Currency      string     `json:"currency"`
+	Count         int        `json:"count"`
+}
+
+func runSubAdd(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	amountCents, err := parsePositiveAmount(args[0])
+	if err != nil {
+		return err
+	}
+
+	customer := strings.TrimSpace(subCustomer)
+	if customer == "" {
+		return fmt.Errorf("customer is required")
+	}
+	newCustomer := false
+	if id, err := resolveCustomer(store, customer); err == nil {
+		c, err := store.GetCustomer(id)
+		if err != nil {
+			return err
+		}
+		customer = c.Name
+	} else {
+		newCustomer = true
+	}
+
+	if !models.IsValidInterval(subInterval) {
+		return fmt.Errorf("invalid interval: %s (valid: %v)", subInterval, models.ValidIntervals)
+	}
+
+	if !models.IsValidSource(subSource) {
+		return fmt.Errorf("invalid source: %s (valid: %v)", subSource, models.ValidSources)
+	}
+
+	currency, err := parseCurrency(store, subCurrency)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if subStart != "" {
+		start, err = time.Parse("2006-01-02", subStart)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", subStart)
+		}
+	}
+
+	id, err := store.AddSubscription(customer, amountCents, currency, subInterval, subSource, subNote, start)
+	if err != nil {
+		return err
+	}
+
+	sub := models.Subscription{Amount: amountCents, Interval: subInterval}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	if newCustomer {
+		fmt.Printf("%s Added customer %s\n", green("✓"), cyan(customer))
+	}
+	fmt.Printf("%s Added subscription #%s: %s/%s for %s (%s MRR)\n",
+		green("✓"),
+		cyan(fmt.Sprintf("%d", id)),
+		models.FormatAmount(amountCents, currency),
+		subInterval,
+		customer,
+		models.FormatAmount(sub.MonthlyAmount(), currency),
+	)
+
+	return nil
+}
+
+func runSubCancel(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID: %s", args[0])
+	}
+
+	cancelDate := time.Now()
+	if subCancelAt != "" {
+		cancelDate, err = time.Parse("2006-01-02", subCancelAt)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %s (use YYYY-MM-DD)", subCancelAt)
+		}
+	}
+
+	if err := store.CancelSubscription(id, cancelDate); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s Cancelled subscription #%s as of %s\n",
+		green("✓"),
+		cyan(fmt.Sprintf("%d", id)),
+		cancelDate.Format("2006-01-02"),
+	)
+
+	return nil
+}
+
+func runSubList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	subs, err := store.ListSubscriptions(subActive)
+	if err != nil {
+		return err
+	}
+
+	mrr, err := activeSubscriptionMRR(store, subs)
+	if err != nil {
+		return err
+	}
+
+	if subJSON {
+		return subListAsJSON(subs, mrr, store.ReportingCurrency())
+	}
+
+	if len(subs) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No subscriptions found.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Customer", "Amount", "MRR", "Source", "Start", "Cancelled"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, s := range subs {
+		cancelled := ""
+		mrrColor := tablewriter.FgGreenColor
+		if s.CancelDate != nil {
+			cancelled = s.CancelDate.Format("2006-01-02")
+			mrrColor = tablewriter.FgRedColor
+		}
+
+		table.Rich([]string{
+			fmt.Sprintf("%d", s.ID),
+			s.Customer,
+			fmt.Sprintf("%s/%s", models.FormatAmount(s.Amount, s.Currency), s.Interval),
+			models.FormatAmount(s.MonthlyAmount(), s.Currency),
+			s.Source,
+			s.StartDate.Format("2006-01-02"),
+			cancelled,
+		}, []tablewriter.Colors{
+			{},
+			{},
+			{},
+			{mrrColor},
+			{tablewriter.FgMagentaColor},
+			{},
+			{tablewriter.FgRedColor},
+		})
+	}
+
+	table.Render()
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("\n%s subscriptions, active MRR: %s\n", cyan(fmt.Sprintf("%d", len(subs))), green(models.FormatAmount(mrr, store.ReportingCurrency())))
+
+	return nil
+}
+
+func subListAsJSON(subs []models.Subscription, mrr int64, currency string) error {
+	output := subListOutput{Subscriptions: []subEntry{}}
+
+	for _, s := range subs {
+		entry := subEntry{
+			ID:         s.ID,
+			Customer:   s.Customer,
+			CustomerID: s.CustomerID,
+			Amount:     float64(s.Amount) / 100.0,
+			Currency:   s.Currency,
+			Interval:   s.Interval,
+			MRR:        float64(s.MonthlyAmount()) / 100.0,
+			Source:     s.Source,
+			StartDate:  s.StartDate.Format("2006-01-02"),
+			Note:       s.Note,
+		}
+		if s.CancelDate != nil {
+			entry.CancelDate = s.CancelDate.Format("2006-01-02")
+		}
+		output.Subscriptions = append(output.Subscriptions, entry)
+	}
+
+	output.MRR = float64(mrr) / 100.0
+	output.Currency = currency
+	output.Count = len(subs)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// activeSubscriptionMRR totals the MRR of subscriptions active today in the
+// reporting currency
+func activeSubscriptionMRR(store db.Store, subs []models.Subscription) (int64, error) {
+	fx, err := db.LoadFXTable(store)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var mrr int64
+	for _, s := range subs {
+		if !s.IsActiveAt(now) {
+			continue
+		}
+		amount, err := fx.Convert(s.MonthlyAmount(), s.Currency, store.ReportingCurrency(), now.Format("2006-01"))
+		if err != nil {
+			return 0, err
+		}
+		mrr += amount
+	}
+	return mrr, nil
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/importer"
+	"github.com/indiekitai/mrr-cli/models"
+	"github.com/indiekitai/mrr-cli/stripeapi"
+)
+
+// StripeConfig holds the Stripe account the workspace syncs from and the
+// secret its webhooks are signed with
+type StripeConfig struct {
+	APIKey  string `json:"api_key,omitempty"`
+	BaseURL string `json:"base_url,omitempty"` // API base URL, e.g. a local stripe-mock
+
+	WebhookSecret string `json:"webhook_secret,omitempty"` // Signing secret of the /webhooks/stripe endpoint
+}
+
+// syncOverlap is how far before the cursor each sync looks again, so
+// invoices created before the last sync but paid after it are not missed.
+// Objects seen before are recognised by their import keys.
+const syncOverlap = 7 * 24 * time.Hour
+
+var (
+	syncAPIKey  string
+	syncBaseURL string
+	syncFull    bool
+	syncDryRun  bool
+	syncSave    bool
+	syncJSON    bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync revenue from a payment provider's API",
+	Long: `Pull revenue directly from a payment provider instead of importing exports.
+
+Synced entries are marked with their origin, so 'mrr list' can tell them
+apart from entries added by hand or imported from files.
+
+Examples:
+  mrr sync stripe                          # Fetch what changed since the last sync
+  mrr sync stripe --full                   # Fetch everything again`,
+}
+
+var syncStripeCmd = &cobra.Command{
+	Use:   "stripe",
+	Short: "Sync subscriptions, invoices and refunds from Stripe",
+	Long: `Sync subscriptions, paid invoices and refunds from the Stripe API.
+
+Subscriptions carry MRR; invoices paid for them and their refunds are
+skipped so the revenue isn't counted twice. One-off invoices and their
+refunds are stored as one-time revenue.
+
+Invoices and refunds are fetched incrementally: each sync remembers the
+newest object it saw and the next one starts from there. Subscriptions are
+always fetched in full so cancellations are picked up. Objects that were
+synced or imported before are updated or skipped, never duplicated.
+
+The API key is read from --api-key, STRIPE_API_KEY or the workspace config
+(saved with --save). A restricted key with read access to subscriptions,
+invoices, charges and refunds is enough. Use --base-url or STRIPE_API_BASE
+to sync from stripe-mock or another compatible server.
+
+Examples:
+  mrr sync stripe --api-key rk_live_... --save   # Sync and remember the key
+  mrr sync stripe                                # Incremental sync
+  mrr sync stripe --dry-run                      # Show what would change
+  mrr sync stripe --base-url http://localhost:12111 --api-key sk_test_123`,
+	Args: cobra.NoArgs,
+	RunE: runSyncStripe,
+}
+
+func init() {
+	syncStripeCmd.Flags().StringVar(&syncAPIKey, "api-key", "", "Stripe secret or restricted key (default: STRIPE_API_KEY or config)")
+	syncStripeCmd.Flags().StringVar(&syncBaseURL, "base-url", "", "Stripe API base URL (default: STRIPE_API_BASE, config or "+stripeapi.DefaultBaseURL+")")
+	syncStripeCmd.Flags().BoolVar(&syncFull, "full", false, "Ignore the sync cursor and fetch everything")
+	syncStripeCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would be synced without writing anything")
+	syncStripeCmd.Flags().BoolVar(&syncSave, "save", false, "Save the API key and base URL in the workspace config")
+	syncStripeCmd.Flags().BoolVarP(&syncJSON, "json", "j", false, "Output the result as JSON")
+
+	syncCmd.AddCommand(syncStripeCmd)
+}
+
+func runSyncStripe(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	stripeConfig := config.Stripe
+	if stripeConfig == nil {
+		stripeConfig = &StripeConfig{}
+	}
+
+	apiKey := firstNonEmpty(syncAPIKey, os.Getenv("STRIPE_API_KEY"), stripeConfig.APIKey)
+	if apiKey == "" {
+		return fmt.Errorf("no Stripe API key: use --api-key or set STRIPE_API_KEY")
+	}
+	baseURL := firstNonEmpty(syncBaseURL, os.Getenv("STRIPE_API_BASE"), stripeConfig.BaseURL)
+
+	if syncSave {
+		stripeConfig.APIKey = apiKey
+		stripeConfig.BaseURL = baseURL
+		config.Stripe = stripeConfig
+		if err := saveConfig(config); err != nil {
+			return err
+		}
+	}
+
+	client := stripeapi.NewClient(apiKey, baseURL)
+	output, next, err := syncStripe(store, client, syncFull, syncDryRun)
+	if err != nil {
+		return err
+	}
+
+	if err := finishImport(&output, syncJSON); err != nil {
+		return err
+	}
+
+	if !syncJSON && output.Committed && next > 0 {
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("%s Synced up to %s\n", cyan("ℹ"), time.Unix(next, 0).UTC().Format("2006-01-02 15:04 MST"))
+	}
+
+	return nil
+}
+
+// syncStripe fetches what changed in the Stripe account since the sync
+// cursor (everything if full) and stores it in s, returning the outcome and
+// the new cursor
+func syncStripe(s db.Store, client *stripeapi.Client, full, dryRun bool) (importOutput, int64, error) {
+	cursor := int64(0)
+	if !full {
+		var err error
+		if cursor, err = s.GetSyncCursor("stripe"); err != nil {
+			return importOutput{}, 0, err
+		}
+	}
+	since := int64(0)
+	if cursor > 0 {
+		since = cursor - int64(syncOverlap/time.Second)
+	}
+
+	subs, err := client.ListSubscriptions()
+	if err != nil {
+		return importOutput{}, 0, err
+	}
+	invoices, err := client.ListPaidInvoices(since)
+	if err != nil {
+		return importOutput{}, 0, err
+	}
+	refunds, err := client.ListRefunds(since)
+	if err != nil {
+		return importOutput{}, 0, err
+	}
+
+	// Advance the cursor to the newest invoice or refund seen; subscriptions
+	// are fetched in full every time and don't move it
+	next := cursor
+	for _, inv := range invoices {
+		if inv.Created > next {
+			next = inv.Created
+		}
+	}
+	for _, refund := range refunds {
+		if refund.Created > next {
+			next = refund.Created
+		}
+	}
+
+	output := newImportOutput(client.BaseURL, "stripe-api")
+	if output.File == "" {
+		output.File = stripeapi.DefaultBaseURL
+	}
+	output.DryRun = dryRun
+
+	err = storeImport(s, importer.FromStripe(subs, invoices, refunds), importSettings{
+		currency: s.ReportingCurrency(),
+		origin:   models.OriginSync,
+		after: func(tx db.Store) error {
+			return tx.SetSyncCursor("stripe", next)
+		},
+	}, &output)
+	return output, next, err
+}
+
+// firstNonEmpty returns the first of values that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
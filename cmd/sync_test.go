@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/models"
+	"github.com/indiekitai/mrr-cli/stripeapi"
+)
+
+// stripeServer serves one $50/month subscription and the invoice it paid,
+// with pages replacing the responses for some paths
+func stripeServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+
+	start := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC).Unix()
+
+	defaults := map[string]string{
+		"/v1/subscriptions": fmt.Sprintf(`{"data": [{
+			"id": "sub_1", "status": "active", "created": %d, "start_date": %d,
+			"customer": {"id": "cus_1", "name": "Acme"},
+			"items": {"data": [{"quantity": 1, "price": {
+				"id": "price_1", "currency": "usd", "unit_amount": 5000,
+				"recurring": {"interval": "month", "interval_count": 1}
+			}}]}
+		}], "has_more": false}`, start, start),
+		"/v1/invoices": fmt.Sprintf(`{"data": [{
+			"id": "in_1", "status": "paid", "currency": "usd", "amount_paid": 5000,
+			"created": %d, "customer": "cus_1", "subscription": "sub_1",
+			"status_transitions": {"paid_at": %d},
+			"lines": {"data": [{"period": {"start": %d, "end": %d}}]}
+		}], "has_more": false}`, start, start, start, end),
+		"/v1/refunds": `{"data": [], "has_more": false}`,
+	}
+	for path, page := range pages {
+		defaults[path] = page
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := defaults[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSyncStripeCountsSubscriptionOnce(t *testing.T) {
+	s := db.NewMemoryStore()
+	client := stripeapi.NewClient("sk_test", stripeServer(t, nil).URL)
+
+	output, _, err := syncStripe(s, client, false, false)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !output.Committed {
+		t.Fatalf("sync was not committed: %+v", output)
+	}
+
+	report, err := db.GetMonthlyReport(s, "2026-09")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.RecurringRevenue != 5000 {
+		t.Errorf("MRR = %d, want 5000 (subscription %d, adjustments %d)",
+			report.RecurringRevenue, report.SubscriptionMRR, report.AdjustmentMRR)
+	}
+	if report.TotalRevenue != 5000 {
+		t.Errorf("total revenue = %d, want 5000", report.TotalRevenue)
+	}
+}
+
+func TestSyncStripeRemovesSupersededInvoiceEntries(t *testing.T) {
+	s := db.NewMemoryStore()
+
+	// Earlier syncs stored subscription invoices as recurring entries
+	_, err := s.ImportEntry(&models.Entry{
+		Amount:    5000,
+		Currency:  "USD",
+		Source:    "stripe",
+		Type:      "recurring",
+		Date:      time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		ImportKey: "stripe:in_1",
+		Origin:    models.OriginSync,
+	})
+	if err != nil {
+		t.Fatalf("import entry: %v", err)
+	}
+
+	client := stripeapi.NewClient("sk_test", stripeServer(t, nil).URL)
+	output, _, err := syncStripe(s, client, true, false)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if output.Removed != 1 {
+		t.Errorf("removed = %d, want 1", output.Removed)
+	}
+
+	mrr, err := db.GetMonthMRR(s, "2026-09")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if mrr != 5000 {
+		t.Errorf("MRR = %d, want 5000", mrr)
+	}
+}
+
+func TestSyncStripeKeepsOneOffRefunds(t *testing.T) {
+	paid := time.Date(2026, 9, 10, 12, 0, 0, 0, time.UTC).Unix()
+	refunded := time.Date(2026, 9, 12, 12, 0, 0, 0, time.UTC).Unix()
+
+	client := stripeapi.NewClient("sk_test", stripeServer(t, map[string]string{
+		"/v1/invoices": fmt.Sprintf(`{"data": [
+			{"id": "in_1", "status": "paid", "currency": "usd", "amount_paid": 5000,
+			 "created": %d, "customer": "cus_1", "subscription": "sub_1"},
+			{"id": "in_2", "status": "paid", "currency": "usd", "amount_paid": 2000,
+			 "created": %d, "customer": "cus_1", "description": "Setup"}
+		], "has_more": false}`, paid, paid),
+		"/v1/refunds": fmt.Sprintf(`{"data": [
+			{"id": "re_1", "status": "succeeded", "currency": "usd", "amount": 2000, "created": %d,
+			 "charge": {"id": "ch_2", "invoice": {"id": "in_2", "subscription": null}, "customer": "cus_1"}},
+			{"id": "re_2", "status": "succeeded", "currency": "usd", "amount": 5000, "created": %d,
+			 "charge": {"id": "ch_1", "invoice": {"id": "in_1", "subscription": "sub_1"}, "customer": "cus_1"}},
+			{"id": "re_3", "status": "succeeded", "currency": "usd", "amount": 5000, "created": %d,
+			 "charge": {"id": "ch_3", "invoice": "in_1", "customer": "cus_1"}}
+		], "has_more": false}`, refunded, refunded, refunded),
+	}).URL)
+
+	s := db.NewMemoryStore()
+	if _, _, err := syncStripe(s, client, false, false); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	entries, err := s.ListEntries("", "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make(map[string]int64)
+	for _, e := range entries {
+		keys[e.ImportKey] = e.Amount
+	}
+	want := map[string]int64{"stripe:in_2": 2000, "stripe:re_1": -2000}
+	if len(keys) != len(want) {
+		t.Errorf("entries = %v, want %v", keys, want)
+	}
+	for key, amount := range want {
+		if keys[key] != amount {
+			t.Errorf("%s = %d, want %d", key, keys[key], amount)
+		}
+	}
+
+	report, err := db.GetMonthlyReport(s, "2026-09")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.OneTimeRevenue != 0 || report.RecurringRevenue != 5000 {
+		t.Errorf("one-time %d, MRR %d, want 0 and 5000", report.OneTimeRevenue, report.RecurringRevenue)
+	}
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// tokenPrefix starts every API token, so leaked tokens are easy to spot
+const tokenPrefix = "mrr_"
+
+var (
+	tokenScope string
+	tokenJSON  bool
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage REST API tokens",
+	Long: `Manage the bearer tokens that authenticate requests to the REST API
+served by 'mrr serve' at /api/v1.
+
+Read tokens can only fetch data; read-write tokens can also add, edit and
+delete entries. Tokens are shown once when created and stored hashed.
+
+Examples:
+  mrr token create dashboard                    # Read-only token
+  mrr token create zapier --scope read-write
+  mrr token list
+  mrr token revoke zapier`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTokenCreate,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	RunE:  runTokenList,
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <name|id>",
+	Short: "Revoke an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTokenRevoke,
+}
+
+func init() {
+	tokenCreateCmd.Flags().StringVar(&tokenScope, "scope", models.ScopeRead, fmt.Sprintf("Token scope (%s)", strings.Join(models.ValidScopes, ", ")))
+	tokenListCmd.Flags().BoolVarP(&tokenJSON, "json", "j", false, "Output as JSON")
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+}
+
+// hashToken returns the hash a token is stored and looked up by
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func runTokenCreate(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("token name is required")
+	}
+	if !models.IsValidScope(tokenScope) {
+		return fmt.Errorf("invalid scope: %s (valid: %v)", tokenScope, models.ValidScopes)
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+
+	id, err := store.AddAPIToken(name, tokenScope, hashToken(token), token[:len(tokenPrefix)+8])
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Printf("%s Created %s token #%s: %s\n", green("✓"), tokenScope, cyan(fmt.Sprintf("%d", id)), name)
+	fmt.Println()
+	fmt.Printf("  %s\n", token)
+	fmt.Println()
+	fmt.Printf("%s Copy it now, it won't be shown again.\n", yellow("⚠"))
+
+	return nil
+}
+
+type tokenEntry struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Scope      string `json:"scope"`
+	Prefix     string `json:"prefix"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+}
+
+func runTokenList(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	tokens, err := store.ListAPITokens()
+	if err != nil {
+		return err
+	}
+
+	if tokenJSON {
+		output := []tokenEntry{}
+		for _, t := range tokens {
+			entry := tokenEntry{
+				ID:        t.ID,
+				Name:      t.Name,
+				Scope:     t.Scope,
+				Prefix:    t.Prefix,
+				CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			}
+			if t.LastUsedAt != nil {
+				entry.LastUsedAt = t.LastUsedAt.Format("2006-01-02T15:04:05Z")
+			}
+			output = append(output, entry)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(tokens) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s No API tokens. Create one with 'mrr token create <name>'.\n", yellow("⚠"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Scope", "Token", "Created", "Last Used"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, t := range tokens {
+		scopeColor := tablewriter.FgGreenColor
+		if t.CanWrite() {
+			scopeColor = tablewriter.FgYellowColor
+		}
+		lastUsed := "never"
+		if t.LastUsedAt != nil {
+			lastUsed = t.LastUsedAt.Format("2006-01-02 15:04")
+		}
+
+		table.Rich([]string{
+			fmt.Sprintf("%d", t.ID),
+			t.Name,
+			t.Scope,
+			t.Prefix + "…",
+			t.CreatedAt.Format("2006-01-02"),
+			lastUsed,
+		}, []tablewriter.Colors{
+			{},
+			{tablewriter.FgBlueColor},
+			{scopeColor},
+			{},
+			{},
+			{},
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+func runTokenRevoke(cmd *cobra.Command, args []string) error {
+	store := storeFrom(cmd)
+
+	tokens, err := store.ListAPITokens()
+	if err != nil {
+		return err
+	}
+
+	ref := strings.TrimSpace(args[0])
+	var token *models.APIToken
+	for i, t := range tokens {
+		if t.Name == ref || strconv.FormatInt(t.ID, 10) == ref {
+			token = &tokens[i]
+			break
+		}
+	}
+	if token == nil {
+		return fmt.Errorf("token not found: %s", ref)
+	}
+
+	if err := store.DeleteAPIToken(token.ID); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Revoked token #%d: %s\n", green("✓"), token.ID, token.Name)
+
+	return nil
+}
@@ -20,6 +20,6 @@ Keybindings:
   r       - Refresh
   q/Esc   - Quit`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return ui.Run()
+		return ui.Run(storeFrom(cmd))
 	},
 }
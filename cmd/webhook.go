@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+	"github.com/indiekitai/mrr-cli/importer"
+	"github.com/indiekitai/mrr-cli/models"
+	"github.com/indiekitai/mrr-cli/webhook"
+)
+
+// PaddleConfig holds the secret Paddle signs the workspace's webhooks with
+type PaddleConfig struct {
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+}
+
+// GumroadConfig holds the token Gumroad pings must carry, since Gumroad
+// doesn't sign them
+type GumroadConfig struct {
+	WebhookToken string `json:"webhook_token,omitempty"`
+}
+
+// maxWebhookBody caps the size of a webhook request
+const maxWebhookBody = 1 << 20
+
+// webhookProviders are the providers mrr serve accepts webhooks from
+var webhookProviders = []string{"stripe", "paddle", "gumroad"}
+
+// webhookSecretEnv names the environment variable overriding each
+// provider's configured secret
+var webhookSecretEnv = map[string]string{
+	"stripe":  "STRIPE_WEBHOOK_SECRET",
+	"paddle":  "PADDLE_WEBHOOK_SECRET",
+	"gumroad": "GUMROAD_WEBHOOK_TOKEN",
+}
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage webhook secrets for mrr serve",
+	Long: `Manage the secrets mrr serve verifies webhooks with.
+
+'mrr serve' accepts webhooks at /webhooks/stripe, /webhooks/paddle and
+/webhooks/gumroad once the provider's secret is set. Payments, refunds and
+subscription changes are recorded as they happen, with origin 'webhook'.
+
+Stripe and Paddle sign every request with the endpoint's secret. Gumroad
+doesn't sign its pings, so the ping URL carries a token instead.
+
+Secrets can also be given with STRIPE_WEBHOOK_SECRET, PADDLE_WEBHOOK_SECRET
+and GUMROAD_WEBHOOK_TOKEN.
+
+Examples:
+  mrr webhook set stripe whsec_...    # Signing secret from the Stripe dashboard
+  mrr webhook set paddle pdl_ntfset_...
+  mrr webhook set gumroad             # Generate a token for the ping URL
+  mrr webhook list`,
+}
+
+var webhookSetCmd = &cobra.Command{
+	Use:   "set <provider> [secret]",
+	Short: "Set a provider's webhook secret",
+	Long: `Set the secret a provider's webhooks are verified with.
+
+For Gumroad the secret is optional; a random token is generated if it is
+left out. Set an empty secret ("") to disable a provider's endpoint.
+
+Examples:
+  mrr webhook set stripe whsec_...
+  mrr webhook set gumroad
+  mrr webhook set paddle ""           # Disable /webhooks/paddle`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runWebhookSet,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhook endpoints and whether they are enabled",
+	RunE:  runWebhookList,
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookSetCmd)
+	webhookCmd.AddCommand(webhookListCmd)
+}
+
+func runWebhookSet(cmd *cobra.Command, args []string) error {
+	provider := strings.ToLower(args[0])
+
+	var secret string
+	if len(args) == 2 {
+		secret = strings.TrimSpace(args[1])
+	} else if provider == "gumroad" {
+		token := make([]byte, 16)
+		if _, err := rand.Read(token); err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		secret = hex.EncodeToString(token)
+	} else {
+		return fmt.Errorf("the %s webhook secret is required", provider)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	switch provider {
+	case "stripe":
+		if config.Stripe == nil {
+			config.Stripe = &StripeConfig{}
+		}
+		config.Stripe.WebhookSecret = secret
+	case "paddle":
+		if config.Paddle == nil {
+			config.Paddle = &PaddleConfig{}
+		}
+		config.Paddle.WebhookSecret = secret
+	case "gumroad":
+		if config.Gumroad == nil {
+			config.Gumroad = &GumroadConfig{}
+		}
+		config.Gumroad.WebhookToken = secret
+	default:
+		return fmt.Errorf("invalid provider: %s (valid: %v)", provider, webhookProviders)
+	}
+
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	if secret == "" {
+		fmt.Printf("%s %s webhooks disabled\n", green("✓"), provider)
+		return nil
+	}
+	fmt.Printf("%s %s webhook secret saved\n", green("✓"), provider)
+	if provider == "gumroad" {
+		fmt.Printf("  Ping URL: %s\n", cyan("https://<your-host>/webhooks/gumroad?token="+secret))
+	} else {
+		fmt.Printf("  Endpoint: %s\n", cyan("https://<your-host>/webhooks/"+provider))
+	}
+
+	return nil
+}
+
+func runWebhookList(cmd *cobra.Command, args []string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	secrets := webhookSecrets(config)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Provider", "Endpoint", "Status"})
+	table.SetBorder(false)
+	table.SetHeaderColor(
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+		tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor},
+	)
+
+	for _, provider := range webhookProviders {
+		status, statusColor := "disabled", tablewriter.FgYellowColor
+		if secrets.get(provider) != "" {
+			status, statusColor = "enabled", tablewriter.FgGreenColor
+			if os.Getenv(webhookSecretEnv[provider]) != "" {
+				status += " (" + webhookSecretEnv[provider] + ")"
+			}
+		}
+		table.Rich([]string{provider, "/webhooks/" + provider, status}, []tablewriter.Colors{
+			{tablewriter.FgMagentaColor},
+			{},
+			{statusColor},
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// WebhookSecrets are the secrets each provider's webhooks are verified
+// with. A provider without a secret has its endpoint disabled.
+type WebhookSecrets struct {
+	Stripe  string // Endpoint signing secret (whsec_...)
+	Paddle  string // Notification destination secret key
+	Gumroad string // Token in the ping URL's token parameter
+}
+
+func (s WebhookSecrets) get(provider string) string {
+	switch provider {
+	case "stripe":
+		return s.Stripe
+	case "paddle":
+		return s.Paddle
+	case "gumroad":
+		return s.Gumroad
+	}
+	return ""
+}
+
+// webhookSecrets reads the configured secrets, letting the environment
+// override them
+func webhookSecrets(config *Config) WebhookSecrets {
+	var secrets WebhookSecrets
+	if config.Stripe != nil {
+		secrets.Stripe = config.Stripe.WebhookSecret
+	}
+	if config.Paddle != nil {
+		secrets.Paddle = config.Paddle.WebhookSecret
+	}
+	if config.Gumroad != nil {
+		secrets.Gumroad = config.Gumroad.WebhookToken
+	}
+
+	secrets.Stripe = firstNonEmpty(os.Getenv(webhookSecretEnv["stripe"]), secrets.Stripe)
+	secrets.Paddle = firstNonEmpty(os.Getenv(webhookSecretEnv["paddle"]), secrets.Paddle)
+	secrets.Gumroad = firstNonEmpty(os.Getenv(webhookSecretEnv["gumroad"]), secrets.Gumroad)
+
+	return secrets
+}
+
+// webhookServer records webhook events in a store
+type webhookServer struct {
+	store   db.Store
+	secrets WebhookSecrets
+	log     io.Writer
+	mu      sync.Mutex // Events are stored one at a time
+}
+
+// NewWebhookHandler returns the handler for /webhooks/stripe,
+// /webhooks/paddle and /webhooks/gumroad, recording verified events in s.
+// Each handled event is logged as a line to log, if not nil.
+func NewWebhookHandler(s db.Store, secrets WebhookSecrets, log io.Writer) http.Handler {
+	srv := &webhookServer{store: s, secrets: secrets, log: log}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhooks/stripe", func(w http.ResponseWriter, r *http.Request) {
+		srv.receive(w, r, "stripe",
+			func(body []byte) error {
+				return webhook.VerifyStripe(body, r.Header.Get("Stripe-Signature"), secrets.Stripe, time.Now())
+			},
+			importer.FromStripeEvent)
+	})
+	mux.HandleFunc("/webhooks/paddle", func(w http.ResponseWriter, r *http.Request) {
+		srv.receive(w, r, "paddle",
+			func(body []byte) error {
+				return webhook.VerifyPaddle(body, r.Header.Get("Paddle-Signature"), secrets.Paddle, time.Now())
+			},
+			importer.FromPaddleEvent)
+	})
+	mux.HandleFunc("/webhooks/gumroad", func(w http.ResponseWriter, r *http.Request) {
+		srv.receive(w, r, "gumroad",
+			func(body []byte) error {
+				return webhook.VerifyToken(r.URL.Query().Get("token"), secrets.Gumroad)
+			},
+			func(body []byte) (*importer.Result, error) {
+				form, err := url.ParseQuery(string(body))
+				if err != nil {
+					return nil, fmt.Errorf("invalid Gumroad ping: %w", err)
+				}
+				return importer.FromGumroadPing(form, time.Now())
+			})
+	})
+
+	return mux
+}
+
+// receive verifies a webhook request, converts its event into rows and
+// stores them. Verification and parse failures are client errors; storage
+// failures are server errors so the provider retries the event.
+func (srv *webhookServer) receive(w http.ResponseWriter, r *http.Request, provider string,
+	verify func(body []byte) error, parse func(body []byte) (*importer.Result, error)) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if srv.secrets.get(provider) == "" {
+		http.Error(w, provider+" webhooks are not enabled", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := verify(body); err != nil {
+		srv.logf("%s: rejected request from %s: %v", provider, r.RemoteAddr, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := parse(body)
+	if err != nil {
+		srv.logf("%s: %v", provider, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	srv.mu.Lock()
+	output := newImportOutput("/webhooks/"+provider, provider+"-webhook")
+	err = storeImport(srv.store, result, importSettings{
+		currency: srv.store.ReportingCurrency(),
+		origin:   models.OriginWebhook,
+	}, &output)
+	srv.mu.Unlock()
+	if err != nil {
+		srv.logf("%s: %v", provider, err)
+		http.Error(w, "failed to store event", http.StatusInternalServerError)
+		return
+	}
+
+	summary := fmt.Sprintf("%d inserted, %d updated, %d duplicates", output.Inserted, output.Updated, output.Duplicates)
+	for _, issue := range append(output.Skipped, output.Rejected...) {
+		summary += "; " + issue.Reason
+	}
+	srv.logf("%s: %s", provider, summary)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (srv *webhookServer) logf(format string, args ...interface{}) {
+	if srv.log == nil {
+		return
+	}
+	fmt.Fprintf(srv.log, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+// sign returns the HMAC-SHA256 signature header a provider would send
+func sign(t *testing.T, provider, secret, payload string) (string, string) {
+	t.Helper()
+
+	ts := fmt.Sprint(time.Now().Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	switch provider {
+	case "stripe":
+		mac.Write([]byte(ts + "." + payload))
+		return "Stripe-Signature", "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+	case "paddle":
+		mac.Write([]byte(ts + ":" + payload))
+		return "Paddle-Signature", "ts=" + ts + ";h1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	t.Fatalf("unknown provider %s", provider)
+	return "", ""
+}
+
+// deliver posts signed events to a webhook endpoint
+func deliver(t *testing.T, handler http.Handler, provider, secret string, events ...string) {
+	t.Helper()
+
+	for _, event := range events {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+provider, strings.NewReader(event))
+		req.Header.Set(sign(t, provider, secret, event))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s event: HTTP %d: %s", provider, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestWebhooksCountSubscriptionsOnce(t *testing.T) {
+	start := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	stripeEvents := []string{
+		fmt.Sprintf(`{"id": "evt_1", "type": "customer.subscription.created", "data": {"object": {
+			"id": "sub_1", "status": "active", "start_date": %d, "customer": "cus_1",
+			"items": {"data": [{"quantity": 1, "price": {
+				"id": "price_1", "currency": "usd", "unit_amount": 5000,
+				"recurring": {"interval": "month", "interval_count": 1}
+			}}]}
+		}}}`, start.Unix()),
+		fmt.Sprintf(`{"id": "evt_2", "type": "invoice.paid", "data": {"object": {
+			"id": "in_1", "status": "paid", "currency": "usd", "amount_paid": 5000,
+			"created": %d, "customer": "cus_1", "subscription": "sub_1"
+		}}}`, start.Unix()),
+	}
+
+	paddleEvents := []string{
+		fmt.Sprintf(`{"event_id": "evt_3", "event_type": "subscription.created", "data": {
+			"id": "sub_2", "status": "active", "customer_id": "ctm_1", "currency_code": "USD",
+			"started_at": %q, "billing_cycle": {"interval": "month", "frequency": 1},
+			"items": [{"quantity": 1, "price": {"id": "pri_1", "unit_price": {"amount": "3000", "currency_code": "USD"}}}]
+		}}`, start.Format(time.RFC3339)),
+		fmt.Sprintf(`{"event_id": "evt_4", "event_type": "transaction.completed", "data": {
+			"id": "txn_1", "status": "completed", "origin": "subscription_recurring",
+			"customer_id": "ctm_1", "subscription_id": "sub_2", "currency_code": "USD",
+			"billed_at": %q, "details": {"totals": {"grand_total": "3000"}}
+		}}`, start.Format(time.RFC3339)),
+	}
+
+	s := db.NewMemoryStore()
+	handler := NewWebhookHandler(s, WebhookSecrets{Stripe: "whsec_test", Paddle: "pdl_test"}, nil)
+	deliver(t, handler, "stripe", "whsec_test", stripeEvents...)
+	deliver(t, handler, "paddle", "pdl_test", paddleEvents...)
+
+	report, err := db.GetMonthlyReport(s, "2026-09")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.RecurringRevenue != 8000 {
+		t.Errorf("MRR = %d, want 8000 (subscription %d, adjustments %d)",
+			report.RecurringRevenue, report.SubscriptionMRR, report.AdjustmentMRR)
+	}
+	if report.OneTimeRevenue != 0 {
+		t.Errorf("one-time revenue = %d, want 0", report.OneTimeRevenue)
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/indiekitai/mrr-cli/db"
+)
+
+// defaultWorkspace is the workspace stored directly in ~/.mrr-cli, as
+// before workspaces existed
+const defaultWorkspace = "default"
+
+var (
+	dbPathFlag    string
+	workspaceFlag string
+	workspaceJSON bool
+)
+
+var workspaceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage workspaces for multiple products",
+	Long: `Manage named workspaces. Each workspace has its own database and
+config (goal, reporting currency). Select one with --workspace or MRR_WORKSPACE.
+
+Examples:
+  mrr workspace create saas-b
+  mrr workspace list
+  mrr --workspace saas-b add 29
+  mrr report --all-workspaces`,
+}
+
+var workspaceCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a workspace",
+	Long: `Create a workspace with its own database and config.
+
+Examples:
+  mrr workspace create saas-b`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspaceCreate,
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspaces",
+	RunE:  runWorkspaceList,
+}
+
+func init() {
+	workspaceListCmd.Flags().BoolVarP(&workspaceJSON, "json", "j", false, "Output as JSON")
+
+	workspaceCmd.AddCommand(workspaceCreateCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+}
+
+// dataDir returns the root data directory, ~/.mrr-cli
+func dataDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".mrr-cli"), nil
+}
+
+// currentWorkspace returns the selected workspace name
+func currentWorkspace() string {
+	if workspaceFlag != "" {
+		return workspaceFlag
+	}
+	if env := os.Getenv("MRR_WORKSPACE"); env != "" {
+		return env
+	}
+	return defaultWorkspace
+}
+
+// workspaceDir returns the directory holding a workspace's database and config
+func workspaceDir(name string) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	if name == defaultWorkspace {
+		return dir, nil
+	}
+	return filepath.Join(dir, "workspaces", name), nil
+}
+
+// databasePath returns the database to open: --db, then MRR_DB, then the
+// current workspace's data.db
+func databasePath() (string, error) {
+	if dbPathFlag != "" {
+		return dbPathFlag, nil
+	}
+	if env := os.Getenv("MRR_DB"); env != "" {
+		return env, nil
+	}
+	dir, err := workspaceDir(currentWorkspace())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "data.db"), nil
+}
+
+// listWorkspaces returns the default workspace followed by named ones
+func listWorkspaces() ([]string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{defaultWorkspace}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "workspaces"))
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+
+	return append(names, named...), nil
+}
+
+// checkWorkspace verifies the selected workspace exists
+func checkWorkspace(name string) error {
+	if name == defaultWorkspace {
+		return nil
+	}
+	dir, err := workspaceDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("workspace not found: %s (create it with 'mrr workspace create %s')", name, name)
+	}
+	return nil
+}
+
+// openWorkspace switches the database connection and reporting currency to
+// a workspace for cmd. Used by commands that read several workspaces in turn.
+func openWorkspace(cmd *cobra.Command, name string) (*Config, error) {
+	closeStore(cmd)
+
+	prev := workspaceFlag
+	workspaceFlag = name
+	defer func() { workspaceFlag = prev }()
+
+	dir, err := workspaceDir(name)
+	if err != nil {
+		return nil, err
+	}
+	sqlite, err := db.NewSQLiteStore(filepath.Join(dir, "data.db"))
+	if err != nil {
+		return nil, fmt.Errorf("workspace %s: %w", name, err)
+	}
+	sess := sessionFrom(cmd)
+	sess.store = sqlite
+	sess.owned = true
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("workspace %s: %w", name, err)
+	}
+	sqlite.SetReportingCurrency(config.Currency)
+
+	return config, nil
+}
+
+func runWorkspaceCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name == defaultWorkspace || !workspaceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid workspace name: %s (use lowercase letters, digits, - and _)", name)
+	}
+
+	dir, err := workspaceDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("workspace already exists: %s", name)
+	}
+
+	// Initializing the database creates the directory and schema
+	sqlite, err := db.NewSQLiteStore(filepath.Join(dir, "data.db"))
+	if err != nil {
+		return err
+	}
+	sqlite.Close()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("%s Created workspace %s at %s\n", green("✓"), cyan(name), dir)
+	fmt.Printf("  Use it with: mrr --workspace %s <command>\n", name)
+
+	return nil
+}
+
+type workspaceEntry struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Current bool   `json:"current"`
+}
+
+func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	names, err := listWorkspaces()
+	if err != nil {
+		return err
+	}
+
+	current := currentWorkspace()
+	var output []workspaceEntry
+	for _, name := range names {
+		dir, err := workspaceDir(name)
+		if err != nil {
+			return err
+		}
+		output = append(output, workspaceEntry{Name: name, Path: dir, Current: name == current})
+	}
+
+	if workspaceJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, w := range output {
+		marker := " "
+		name := w.Name
+		if w.Current {
+			marker = green("*")
+			name = green(name)
+		}
+		fmt.Printf("%s %s\n", marker, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// asOfStore is a view of a store as it stood at a point in time
+type asOfStore struct {
+	Store
+	at time.Time
+}
+
+// AsOf returns a view of s as it stood at the end of the day of t: entries
+// dated later are left out, subscriptions starting later don't exist yet and
+// cancellations dated later haven't happened. Reports computed from the view
+// match the ones computed on that day. Writes go straight to s.
+func AsOf(s Store, t time.Time) Store {
+	end := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.UTC)
+	return &asOfStore{Store: s, at: end}
+}
+
+func (v *asOfStore) ListEntries(month, source, entryType string, customerID int64) ([]models.Entry, error) {
+	entries, err := v.Store.ListEntries(month, source, entryType, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := entries[:0]
+	for _, e := range entries {
+		if !e.Date.After(v.at) {
+			visible = append(visible, e)
+		}
+	}
+	return visible, nil
+}
+
+func (v *asOfStore) ListSubscriptions(activeOnly bool) ([]models.Subscription, error) {
+	subs, err := v.Store.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := subs[:0]
+	for _, sub := range subs {
+		if sub.StartDate.After(v.at) {
+			continue
+		}
+		if sub.CancelDate != nil && sub.CancelDate.After(v.at) {
+			sub.CancelDate = nil
+		}
+		if activeOnly && sub.CancelDate != nil {
+			continue
+		}
+		visible = append(visible, sub)
+	}
+	return visible, nil
+}
+
+func (v *asOfStore) ListActiveSubscriptions(at time.Time) ([]models.Subscription, error) {
+	if at.After(v.at) {
+		at = v.at
+	}
+	return v.Store.ListActiveSubscriptions(at)
+}
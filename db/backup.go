@@ -0,0 +1,326 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Backup writes a consistent snapshot of the database to path with SQLite's
+// online backup API, so it is safe while other processes use the database.
+// path must not exist.
+func (s *SQLiteStore) Backup(path string) error {
+	if s.conn == nil {
+		return fmt.Errorf("cannot back up inside a transaction")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup file already exists: %s", path)
+	}
+
+	dest, err := OpenSQLiteStore(path)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	ctx := context.Background()
+	destConn, err := dest.conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer destConn.Close()
+
+	srcConn, err := s.conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer srcConn.Close()
+
+	err = destConn.Raw(func(destDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			backup, err := destDriver.(*sqlite3.SQLiteConn).Backup("main", srcDriver.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that the database is an mrr database this binary can
+// read, returning its schema version
+func (s *SQLiteStore) Validate() (int, error) {
+	var result string
+	if err := s.db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return 0, fmt.Errorf("not a valid SQLite database: %w", err)
+	}
+	if result != "ok" {
+		return 0, fmt.Errorf("database is corrupt: %s", result)
+	}
+
+	var tables int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'entries'").Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to inspect database: %w", err)
+	}
+	if tables == 0 {
+		return 0, fmt.Errorf("not an mrr database: no entries table")
+	}
+
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	if latest := LatestSchemaVersion(); version > latest {
+		return 0, fmt.Errorf("database schema version %d is newer than this version of mrr supports (%d); please upgrade mrr", version, latest)
+	}
+
+	return version, nil
+}
+
+// MergeResult counts what Merge added
+type MergeResult struct {
+	Entries       int
+	Subscriptions int
+	Cancellations int
+	Customers     int
+	FXRates       int
+	Expenses      int
+	Spend         int // Months of acquisition spend
+}
+
+// Merge copies into dst everything in src that dst lacks, in a single
+// transaction. Customers match by name, FX rates by currency and month and
+// acquisition spend by month; existing rates and spend are kept. Entries and
+// subscriptions match by import key, or else on their contents counting
+// repeats, so merging the same data twice adds nothing; expenses match on
+// their contents too.
+// A subscription cancelled or an expense ended only in src is cancelled or
+// ended in dst.
+func Merge(dst, src Store) (*MergeResult, error) {
+	srcCustomers, err := src.ListCustomers()
+	if err != nil {
+		return nil, err
+	}
+	srcEntries, err := src.ListEntries("", "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	srcSubs, err := src.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	srcRates, err := src.ListFXRates()
+	if err != nil {
+		return nil, err
+	}
+	srcExpenses, err := src.ListExpenses("")
+	if err != nil {
+		return nil, err
+	}
+	srcSpend, err := src.ListAcquisitionSpend()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{}
+	err = dst.Transaction(func(tx Store) error {
+		customerIDs := make(map[string]int64)
+		customers, err := tx.ListCustomers()
+		if err != nil {
+			return err
+		}
+		for _, c := range customers {
+			customerIDs[c.Name] = c.ID
+		}
+		for _, c := range srcCustomers {
+			if _, ok := customerIDs[c.Name]; ok {
+				continue
+			}
+			if customerIDs[c.Name], err = tx.AddCustomer(c.Name, c.Email, c.Note); err != nil {
+				return err
+			}
+			result.Customers++
+		}
+
+		rates, err := tx.ListFXRates()
+		if err != nil {
+			return err
+		}
+		haveRate := make(map[string]bool)
+		for _, r := range rates {
+			haveRate[r.Currency+"/"+r.Month] = true
+		}
+		for _, r := range srcRates {
+			if haveRate[r.Currency+"/"+r.Month] {
+				continue
+			}
+			if err := tx.SetFXRate(r.Currency, r.Month, r.Rate); err != nil {
+				return err
+			}
+			result.FXRates++
+		}
+
+		entries, err := tx.ListEntries("", "", "", 0)
+		if err != nil {
+			return err
+		}
+		haveEntries := make(map[string]int)
+		for _, e := range entries {
+			haveEntries[mergeEntryKey(&e)]++
+		}
+		// Oldest first, so entries keep their relative order
+		for i := len(srcEntries) - 1; i >= 0; i-- {
+			e := srcEntries[i]
+			key := mergeEntryKey(&e)
+			if haveEntries[key] > 0 {
+				haveEntries[key]--
+				continue
+			}
+			e.CustomerID = customerIDs[e.CustomerName]
+			if e.ImportKey != "" {
+				if _, err := tx.ImportEntry(&e); err != nil {
+					return err
+				}
+			} else if _, err := tx.AddEntry(e.Amount, e.Currency, e.Source, e.Type, e.Note, e.Date, e.CustomerID); err != nil {
+				return err
+			}
+			result.Entries++
+		}
+
+		subs, err := tx.ListSubscriptions(false)
+		if err != nil {
+			return err
+		}
+		haveSubs := make(map[string][]models.Subscription)
+		for _, sub := range subs {
+			key := mergeSubscriptionKey(&sub)
+			haveSubs[key] = append(haveSubs[key], sub)
+		}
+		for i := len(srcSubs) - 1; i >= 0; i-- {
+			sub := srcSubs[i]
+			key := mergeSubscriptionKey(&sub)
+			if matches := haveSubs[key]; len(matches) > 0 {
+				existing := matches[0]
+				haveSubs[key] = matches[1:]
+				if existing.CancelDate == nil && sub.CancelDate != nil {
+					if err := tx.CancelSubscription(existing.ID, *sub.CancelDate); err != nil {
+						return err
+					}
+					result.Cancellations++
+				}
+				continue
+			}
+
+			if sub.ImportKey != "" {
+				if _, err := tx.ImportSubscription(&sub); err != nil {
+					return err
+				}
+			} else {
+				id, err := tx.AddSubscription(sub.Customer, sub.Amount, sub.Currency, sub.Interval, sub.Source, sub.Note, sub.StartDate)
+				if err != nil {
+					return err
+				}
+				if sub.CancelDate != nil {
+					if err := tx.CancelSubscription(id, *sub.CancelDate); err != nil {
+						return err
+					}
+				}
+			}
+			result.Subscriptions++
+		}
+
+		expenses, err := tx.ListExpenses("")
+		if err != nil {
+			return err
+		}
+		haveExpenses := make(map[string][]models.Expense)
+		for _, e := range expenses {
+			key := mergeExpenseKey(&e)
+			haveExpenses[key] = append(haveExpenses[key], e)
+		}
+		for i := len(srcExpenses) - 1; i >= 0; i-- {
+			e := srcExpenses[i]
+			key := mergeExpenseKey(&e)
+			if matches := haveExpenses[key]; len(matches) > 0 {
+				existing := matches[0]
+				haveExpenses[key] = matches[1:]
+				if existing.EndDate == nil && e.EndDate != nil {
+					if err := tx.UpdateExpense(existing.ID, nil, nil, nil, nil, nil, nil, e.EndDate); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if _, err := tx.AddExpense(e.Amount, e.Currency, e.Category, e.Interval, e.Note, e.Date, e.EndDate); err != nil {
+				return err
+			}
+			result.Expenses++
+		}
+
+		spend, err := tx.ListAcquisitionSpend()
+		if err != nil {
+			return err
+		}
+		haveSpend := make(map[string]bool)
+		for _, sp := range spend {
+			haveSpend[sp.Month] = true
+		}
+		for _, sp := range srcSpend {
+			if haveSpend[sp.Month] {
+				continue
+			}
+			if err := tx.SetAcquisitionSpend(sp.Month, sp.Amount, sp.Currency, sp.Note); err != nil {
+				return err
+			}
+			result.Spend++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge: %w", err)
+	}
+
+	return result, nil
+}
+
+// mergeEntryKey identifies an entry by its import key, or by its contents
+// if it was not imported
+func mergeEntryKey(e *models.Entry) string {
+	if e.ImportKey != "" {
+		return "import:" + e.ImportKey
+	}
+	return fmt.Sprintf("%s|%d|%s|%s|%s|%s|%s",
+		e.Date.Format("2006-01-02"), e.Amount, e.Currency, e.Source, e.Type, e.Note, e.CustomerName)
+}
+
+// mergeExpenseKey identifies an expense by its contents other than its end
+// date
+func mergeExpenseKey(e *models.Expense) string {
+	return fmt.Sprintf("%s|%d|%s|%s|%s|%s",
+		e.Date.Format("2006-01-02"), e.Amount, e.Currency, e.Category, e.Interval, e.Note)
+}
+
+// mergeSubscriptionKey identifies a subscription by its import key, or by its
+// contents other than cancellation if it was not imported
+func mergeSubscriptionKey(sub *models.Subscription) string {
+	if sub.ImportKey != "" {
+		return "import:" + sub.ImportKey
+	}
+	return fmt.Sprintf("%s|%d|%s|%s|%s|%s|%s",
+		sub.Customer, sub.Amount, sub.Currency, sub.Interval, sub.Source, sub.Note, sub.StartDate.Format("2006-01-02"))
+}
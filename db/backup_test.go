@@ -0,0 +1,86 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestMergeExpenses(t *testing.T) {
+	src := NewMemoryStore()
+	dst := NewMemoryStore()
+
+	if _, err := src.AddExpense(2000, "USD", "hosting", "month", "VPS", date("2026-01-01"), nil); err != nil {
+		t.Fatal(err)
+	}
+	end := date("2026-06-30")
+	if _, err := src.AddExpense(1500, "USD", "software", "month", "Editor", date("2026-01-01"), &end); err != nil {
+		t.Fatal(err)
+	}
+	// dst already has the editor, still running
+	if _, err := dst.AddExpense(1500, "USD", "software", "month", "Editor", date("2026-01-01"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := Merge(dst, src)
+		if err != nil {
+			t.Fatalf("merge %d: %v", i+1, err)
+		}
+		want := 1
+		if i > 0 {
+			want = 0
+		}
+		if result.Expenses != want {
+			t.Errorf("merge %d added %d expenses, want %d", i+1, result.Expenses, want)
+		}
+	}
+
+	expenses, err := dst.ListExpenses("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expenses) != 2 {
+		t.Fatalf("got %d expenses, want 2", len(expenses))
+	}
+	for _, e := range expenses {
+		if e.Note == "Editor" && (e.EndDate == nil || !e.EndDate.Equal(end)) {
+			t.Errorf("editor end date = %v, want %s", e.EndDate, end.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestMergeAcquisitionSpend(t *testing.T) {
+	src := NewMemoryStore()
+	dst := NewMemoryStore()
+
+	if err := src.SetAcquisitionSpend("2026-08", 50000, "USD", "Ads"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetAcquisitionSpend("2026-09", 30000, "USD", "Ads"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.SetAcquisitionSpend("2026-09", 40000, "USD", "Sponsorship"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Merge(dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Spend != 1 {
+		t.Errorf("merged %d months of spend, want 1", result.Spend)
+	}
+
+	spend, err := dst.ListAcquisitionSpend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"2026-08": 50000, "2026-09": 40000}
+	if len(spend) != len(want) {
+		t.Fatalf("got %d months of spend, want %d", len(spend), len(want))
+	}
+	for _, sp := range spend {
+		if sp.Amount != want[sp.Month] {
+			t.Errorf("%s: spend = %d, want %d", sp.Month, sp.Amount, want[sp.Month])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Cohort is the customers whose first subscription started in the same
+// period, followed through the periods since
+type Cohort struct {
+	Period    string  // First month of the signup period, YYYY-MM
+	Size      int     // Customers who signed up in the period
+	Customers []int   // Of those, customers paying at the end of each period since signup
+	MRR       []int64 // Their MRR at the end of each period since signup
+}
+
+// GetCohorts groups subscription customers into cohorts by the period of
+// their first subscription, for the signup periods from..to (YYYY-MM, each
+// the first month of a period of periodMonths months). Each cohort is
+// followed up to the period holding until.
+func GetCohorts(s Store, from, to string, periodMonths int, until time.Time) ([]Cohort, error) {
+	start, err := time.Parse("2006-01", from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format: %w", err)
+	}
+	end, err := time.Parse("2006-01", to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end month %s is before start month %s", to, from)
+	}
+	if periodMonths < 1 {
+		return nil, fmt.Errorf("invalid period length: %d months", periodMonths)
+	}
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	signups := customerSignups(subs)
+
+	// periodOf returns the index of the period holding t, counted from start
+	periodOf := func(t time.Time) int {
+		months := (t.Year()-start.Year())*12 + int(t.Month()-start.Month())
+		if months < 0 {
+			return -1
+		}
+		return months / periodMonths
+	}
+	last := periodOf(until)
+
+	// MRR per customer at the end of every period, computed once
+	mrrAt := make([]map[string]int64, last+1)
+	for p := range mrrAt {
+		periodEnd := start.AddDate(0, (p+1)*periodMonths, -1)
+		if mrrAt[p], err = customerMRRAt(subs, fx, s.ReportingCurrency(), periodEnd); err != nil {
+			return nil, err
+		}
+	}
+
+	var cohorts []Cohort
+	for t := start; !t.After(end); t = t.AddDate(0, periodMonths, 0) {
+		p := periodOf(t)
+		cohort := Cohort{Period: t.Format("2006-01")}
+
+		var members []string
+		for customer, signup := range signups {
+			if periodOf(signup) == p {
+				members = append(members, customer)
+			}
+		}
+		cohort.Size = len(members)
+
+		for q := p; q <= last; q++ {
+			paying, mrr := 0, int64(0)
+			for _, customer := range members {
+				if amount := mrrAt[q][customer]; amount > 0 {
+					paying++
+					mrr += amount
+				}
+			}
+			cohort.Customers = append(cohort.Customers, paying)
+			cohort.MRR = append(cohort.MRR, mrr)
+		}
+
+		cohorts = append(cohorts, cohort)
+	}
+
+	return cohorts, nil
+}
+
+// customerSignups returns when each customer's first subscription started
+func customerSignups(subs []models.Subscription) map[string]time.Time {
+	signups := make(map[string]time.Time)
+	for _, sub := range subs {
+		if first, ok := signups[sub.Customer]; !ok || sub.StartDate.Before(first) {
+			signups[sub.Customer] = sub.StartDate
+		}
+	}
+	return signups
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// AddCustomer adds a new customer
+func (s *SQLiteStore) AddCustomer(name, email, note string) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO customers (name, email, note) VALUES (?, ?, ?)",
+		name, email, note,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return 0, fmt.Errorf("customer already exists: %s", name)
+		}
+		return 0, fmt.Errorf("failed to add customer: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetCustomer retrieves a single customer by ID
+func (s *SQLiteStore) GetCustomer(id int64) (*models.Customer, error) {
+	row := s.db.QueryRow("SELECT id, name, email, note, created_at FROM customers WHERE id = ?", id)
+
+	customer, err := scanCustomer(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("customer not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// GetCustomerByName retrieves a single customer by name
+func (s *SQLiteStore) GetCustomerByName(name string) (*models.Customer, error) {
+	row := s.db.QueryRow("SELECT id, name, email, note, created_at FROM customers WHERE name = ?", name)
+
+	customer, err := scanCustomer(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("customer not found: %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// ListCustomers lists all customers ordered by name
+func (s *SQLiteStore) ListCustomers() ([]models.Customer, error) {
+	rows, err := s.db.Query("SELECT id, name, email, note, created_at FROM customers ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customers: %w", err)
+	}
+	defer rows.Close()
+
+	var customers []models.Customer
+	for rows.Next() {
+		customer, err := scanCustomer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		customers = append(customers, *customer)
+	}
+
+	return customers, rows.Err()
+}
+
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	var customer models.Customer
+	var email, note sql.NullString
+	var createdAtStr string
+
+	if err := row.Scan(&customer.ID, &customer.Name, &email, &note, &createdAtStr); err != nil {
+		return nil, err
+	}
+
+	customer.Email = email.String
+	customer.Note = note.String
+	customer.CreatedAt = parseDateTime(createdAtStr)
+
+	return &customer, nil
+}
+
+// CustomerStats summarizes a customer's revenue history. Amounts are in
+// Currency, the reporting currency.
+type CustomerStats struct {
+	Currency        string
+	Customer        models.Customer
+	LifetimeRevenue int64
+	CurrentMRR      int64
+	FirstPayment    *time.Time
+	LastPayment     *time.Time
+	PaymentCount    int
+	Active          bool // Has an active subscription or recurring revenue this month
+}
+
+// TenureMonths returns the number of whole months between first and last
+// payment, or until now for active customers
+func (s *CustomerStats) TenureMonths(now time.Time) int {
+	if s.FirstPayment == nil {
+		return 0
+	}
+	end := *s.LastPayment
+	if s.Active {
+		end = now
+	}
+	months := (end.Year()-s.FirstPayment.Year())*12 + int(end.Month()-s.FirstPayment.Month())
+	if end.Day() < s.FirstPayment.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	return months
+}
+
+// GetCustomerStats computes lifetime revenue, current MRR and payment history
+// for a customer from their entries and subscriptions
+func GetCustomerStats(s Store, id int64, now time.Time) (*CustomerStats, error) {
+	customer, err := s.GetCustomer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CustomerStats{Customer: *customer, Currency: s.ReportingCurrency()}
+	currentMonth := now.Format("2006-01")
+
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	recordPayment := func(amount int64, currency string, date time.Time) error {
+		amount, err := fx.Convert(amount, currency, stats.Currency, date.Format("2006-01"))
+		if err != nil {
+			return err
+		}
+		stats.LifetimeRevenue += amount
+		stats.PaymentCount++
+		if stats.FirstPayment == nil || date.Before(*stats.FirstPayment) {
+			d := date
+			stats.FirstPayment = &d
+		}
+		if stats.LastPayment == nil || date.After(*stats.LastPayment) {
+			d := date
+			stats.LastPayment = &d
+		}
+		return nil
+	}
+
+	entries, err := s.ListEntries("", "", "", id)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := recordPayment(e.Amount, e.Currency, e.Date); err != nil {
+			return nil, err
+		}
+		if e.Type == "recurring" && e.Date.Format("2006-01") == currentMonth {
+			mrr, err := fx.Convert(e.Amount, e.Currency, stats.Currency, currentMonth)
+			if err != nil {
+				return nil, err
+			}
+			stats.CurrentMRR += mrr
+			stats.Active = true
+		}
+	}
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if subs[i].CustomerID != customer.ID {
+			continue
+		}
+		for _, d := range subs[i].BillingDates(now) {
+			if err := recordPayment(subs[i].Amount, subs[i].Currency, d); err != nil {
+				return nil, err
+			}
+		}
+		if subs[i].IsActiveAt(now) {
+			mrr, err := fx.Convert(subs[i].MonthlyAmount(), subs[i].Currency, stats.Currency, currentMonth)
+			if err != nil {
+				return nil, err
+			}
+			stats.CurrentMRR += mrr
+			stats.Active = true
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestSubscriptionsLinkCustomers(t *testing.T) {
+	s := NewMemoryStore()
+
+	if _, err := s.AddSubscription("acme", 2900, "USD", "month", "manual", "", date("2026-08-01")); err != nil {
+		t.Fatal(err)
+	}
+	customer, err := s.GetCustomerByName("acme")
+	if err != nil {
+		t.Fatalf("subscription didn't add its customer: %v", err)
+	}
+
+	// A second subscription for the same name joins the same customer
+	if _, err := s.AddSubscription("acme", 1000, "USD", "month", "manual", "", date("2026-09-01")); err != nil {
+		t.Fatal(err)
+	}
+	customers, err := s.ListCustomers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(customers) != 1 {
+		t.Fatalf("got %d customers, want 1", len(customers))
+	}
+
+	stats, err := GetCustomerStats(s, customer.ID, date("2026-10-15"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CurrentMRR != 3900 {
+		t.Errorf("current MRR = %d, want 3900", stats.CurrentMRR)
+	}
+	// acme paid on Aug 1, Sep 1 and Oct 1, and for the second plan on Sep 1 and Oct 1
+	if stats.LifetimeRevenue != 3*2900+2*1000 {
+		t.Errorf("lifetime revenue = %d, want %d", stats.LifetimeRevenue, 3*2900+2*1000)
+	}
+}
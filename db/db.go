@@ -12,60 +12,88 @@ import (
 	"github.com/indiekitai/mrr-cli/models"
 )
 
-var db *sql.DB
+// SQLiteStore is a Store backed by a SQLite database file
+type SQLiteStore struct {
+	currencySetting
+	conn *sql.DB // nil for a store bound to a transaction
+	db   querier // conn, or the transaction queries run in
+}
+
+// querier is what *sql.DB and *sql.Tx have in common
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
 
-// Init initializes the database connection and creates tables
-func Init() error {
-	homeDir, err := os.UserHomeDir()
+// NewSQLiteStore opens the database at path and applies any pending schema
+// migrations
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	s, err := OpenSQLiteStore(path)
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
 
-	dataDir := filepath.Join(homeDir, ".mrr-cli")
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory: %w", err)
+	if _, err := s.Migrate(); err != nil {
+		s.Close()
+		return nil, err
 	}
 
-	dbPath := filepath.Join(dataDir, "data.db")
-	db, err = sql.Open("sqlite3", dbPath)
+	return s, nil
+}
+
+// OpenSQLiteStore opens the database at path without touching the schema,
+// creating its directory if needed
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	conn, err := sql.Open("sqlite3", path)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Create tables
-	schema := `
-	CREATE TABLE IF NOT EXISTS entries (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		amount INTEGER NOT NULL,
-		source TEXT NOT NULL DEFAULT 'manual',
-		type TEXT NOT NULL DEFAULT 'recurring',
-		note TEXT,
-		date DATE NOT NULL,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-	);
-	CREATE INDEX IF NOT EXISTS idx_entries_date ON entries(date);
-	CREATE INDEX IF NOT EXISTS idx_entries_source ON entries(source);
-	`
+	return &SQLiteStore{conn: conn, db: conn}, nil
+}
 
-	if _, err := db.Exec(schema); err != nil {
-		return fmt.Errorf("failed to create schema: %w", err)
+// Close closes the database connection. Closing a store bound to a
+// transaction does nothing.
+func (s *SQLiteStore) Close() error {
+	if s.conn == nil {
+		return nil
 	}
-
-	return nil
+	return s.conn.Close()
 }
 
-// Close closes the database connection
-func Close() {
-	if db != nil {
-		db.Close()
+// Transaction runs fn in a database transaction, committing if fn returns
+// nil and rolling back otherwise. Nested calls join the outer transaction.
+func (s *SQLiteStore) Transaction(fn func(tx Store) error) error {
+	if s.conn == nil {
+		return fn(s)
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(&SQLiteStore{currencySetting: s.currencySetting, db: tx}); err != nil {
+		return err
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
 }
 
-// AddEntry adds a new revenue entry
-func AddEntry(amount int64, source, entryType, note string, date time.Time) (int64, error) {
-	result, err := db.Exec(
-		"INSERT INTO entries (amount, source, type, note, date) VALUES (?, ?, ?, ?, ?)",
-		amount, source, entryType, note, date.Format("2006-01-02"),
+// AddEntry adds a new revenue entry. customerID may be 0 for no customer.
+func (s *SQLiteStore) AddEntry(amount int64, currency, source, entryType, note string, date time.Time, customerID int64) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO entries (amount, currency, source, type, note, date, customer_id) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		amount, currency, source, entryType, note, date.Format("2006-01-02"), nullableID(customerID),
 	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to add entry: %w", err)
@@ -73,19 +101,15 @@ func AddEntry(amount int64, source, entryType, note string, date time.Time) (int
 	return result.LastInsertId()
 }
 
-// GetEntry retrieves a single entry by ID
-func GetEntry(id int64) (*models.Entry, error) {
-	row := db.QueryRow(
-		"SELECT id, amount, source, type, note, date, created_at FROM entries WHERE id = ?",
-		id,
-	)
+const entryColumns = "e.id, e.amount, e.currency, e.source, e.type, e.note, e.date, e.created_at, e.customer_id, c.name, e.import_key, e.origin"
 
-	var entry models.Entry
-	var dateStr string
-	var createdAtStr string
-	var note sql.NullString
+const entryFrom = " FROM entries e LEFT JOIN customers c ON c.id = e.customer_id"
 
-	err := row.Scan(&entry.ID, &entry.Amount, &entry.Source, &entry.Type, &note, &dateStr, &createdAtStr)
+// GetEntry retrieves a single entry by ID
+func (s *SQLiteStore) GetEntry(id int64) (*models.Entry, error) {
+	row := s.db.QueryRow("SELECT "+entryColumns+entryFrom+" WHERE e.id = ?", id)
+
+	entry, err := scanEntry(row)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("entry not found: %d", id)
 	}
@@ -93,36 +117,34 @@ func GetEntry(id int64) (*models.Entry, error) {
 		return nil, fmt.Errorf("failed to get entry: %w", err)
 	}
 
-	entry.Date = parseDate(dateStr)
-	entry.CreatedAt = parseDateTime(createdAtStr)
-	if note.Valid {
-		entry.Note = note.String
-	}
-
-	return &entry, nil
+	return entry, nil
 }
 
-// ListEntries lists entries with optional filters
-func ListEntries(month string, source string, entryType string) ([]models.Entry, error) {
-	query := "SELECT id, amount, source, type, note, date, created_at FROM entries WHERE 1=1"
+// ListEntries lists entries with optional filters. customerID 0 means any customer.
+func (s *SQLiteStore) ListEntries(month string, source string, entryType string, customerID int64) ([]models.Entry, error) {
+	query := "SELECT " + entryColumns + entryFrom + " WHERE 1=1"
 	args := []interface{}{}
 
 	if month != "" {
-		query += " AND strftime('%Y-%m', date) = ?"
+		query += " AND strftime('%Y-%m', e.date) = ?"
 		args = append(args, month)
 	}
 	if source != "" {
-		query += " AND source = ?"
+		query += " AND e.source = ?"
 		args = append(args, source)
 	}
 	if entryType != "" {
-		query += " AND type = ?"
+		query += " AND e.type = ?"
 		args = append(args, entryType)
 	}
+	if customerID != 0 {
+		query += " AND e.customer_id = ?"
+		args = append(args, customerID)
+	}
 
-	query += " ORDER BY date DESC, id DESC"
+	query += " ORDER BY e.date DESC, e.id DESC"
 
-	rows, err := db.Query(query, args...)
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list entries: %w", err)
 	}
@@ -130,32 +152,62 @@ func ListEntries(month string, source string, entryType string) ([]models.Entry,
 
 	var entries []models.Entry
 	for rows.Next() {
-		var entry models.Entry
-		var dateStr string
-		var createdAtStr string
-		var note sql.NullString
-
-		err := rows.Scan(&entry.ID, &entry.Amount, &entry.Source, &entry.Type, &note, &dateStr, &createdAtStr)
+		entry, err := scanEntry(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
+		entries = append(entries, *entry)
+	}
 
-		entry.Date = parseDate(dateStr)
-		entry.CreatedAt = parseDateTime(createdAtStr)
-		if note.Valid {
-			entry.Note = note.String
-		}
+	return entries, nil
+}
 
-		entries = append(entries, entry)
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEntry(row rowScanner) (*models.Entry, error) {
+	var entry models.Entry
+	var dateStr string
+	var createdAtStr string
+	var note sql.NullString
+	var customerID sql.NullInt64
+	var customerName sql.NullString
+	var importKey sql.NullString
+
+	err := row.Scan(&entry.ID, &entry.Amount, &entry.Currency, &entry.Source, &entry.Type, &note, &dateStr, &createdAtStr,
+		&customerID, &customerName, &importKey, &entry.Origin)
+	if err != nil {
+		return nil, err
 	}
 
-	return entries, nil
+	entry.Date = parseDate(dateStr)
+	entry.CreatedAt = parseDateTime(createdAtStr)
+	if note.Valid {
+		entry.Note = note.String
+	}
+	if customerID.Valid {
+		entry.CustomerID = customerID.Int64
+		entry.CustomerName = customerName.String
+	}
+	entry.ImportKey = importKey.String
+
+	return &entry, nil
+}
+
+// nullableID maps a zero ID to SQL NULL
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
 }
 
-// UpdateEntry updates an existing entry
-func UpdateEntry(id int64, amount *int64, source, note *string) error {
+// UpdateEntry updates an existing entry. A customerID of 0 unlinks the customer.
+func (s *SQLiteStore) UpdateEntry(id int64, amount *int64, currency, source, note *string, customerID *int64) error {
 	// First check if entry exists
-	_, err := GetEntry(id)
+	_, err := s.GetEntry(id)
 	if err != nil {
 		return err
 	}
@@ -167,6 +219,10 @@ func UpdateEntry(id int64, amount *int64, source, note *string) error {
 		updates = append(updates, "amount = ?")
 		args = append(args, *amount)
 	}
+	if currency != nil {
+		updates = append(updates, "currency = ?")
+		args = append(args, *currency)
+	}
 	if source != nil {
 		updates = append(updates, "source = ?")
 		args = append(args, *source)
@@ -175,6 +231,10 @@ func UpdateEntry(id int64, amount *int64, source, note *string) error {
 		updates = append(updates, "note = ?")
 		args = append(args, *note)
 	}
+	if customerID != nil {
+		updates = append(updates, "customer_id = ?")
+		args = append(args, nullableID(*customerID))
+	}
 
 	if len(updates) == 0 {
 		return fmt.Errorf("no fields to update")
@@ -190,7 +250,7 @@ func UpdateEntry(id int64, amount *int64, source, note *string) error {
 	query += " WHERE id = ?"
 	args = append(args, id)
 
-	_, err = db.Exec(query, args...)
+	_, err = s.db.Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update entry: %w", err)
 	}
@@ -199,8 +259,8 @@ func UpdateEntry(id int64, amount *int64, source, note *string) error {
 }
 
 // DeleteEntry deletes an entry by ID
-func DeleteEntry(id int64) error {
-	result, err := db.Exec("DELETE FROM entries WHERE id = ?", id)
+func (s *SQLiteStore) DeleteEntry(id int64) error {
+	result, err := s.db.Exec("DELETE FROM entries WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete entry: %w", err)
 	}
@@ -213,67 +273,6 @@ func DeleteEntry(id int64) error {
 	return nil
 }
 
-// MonthlyReport contains aggregated data for a month
-type MonthlyReport struct {
-	Month           string
-	TotalRevenue    int64
-	RecurringRevenue int64
-	OneTimeRevenue  int64
-	BySource        map[string]int64
-	EntryCount      int
-}
-
-// GetMonthlyReport generates a report for a specific month
-func GetMonthlyReport(month string) (*MonthlyReport, error) {
-	report := &MonthlyReport{
-		Month:    month,
-		BySource: make(map[string]int64),
-	}
-
-	// Get entries for the month
-	entries, err := ListEntries(month, "", "")
-	if err != nil {
-		return nil, err
-	}
-
-	report.EntryCount = len(entries)
-
-	for _, e := range entries {
-		report.TotalRevenue += e.Amount
-		if e.Type == "recurring" {
-			report.RecurringRevenue += e.Amount
-		} else {
-			report.OneTimeRevenue += e.Amount
-		}
-		report.BySource[e.Source] += e.Amount
-	}
-
-	return report, nil
-}
-
-// GetPreviousMonthMRR gets the MRR for the previous month
-func GetPreviousMonthMRR(currentMonth string) (int64, error) {
-	t, err := time.Parse("2006-01", currentMonth)
-	if err != nil {
-		return 0, fmt.Errorf("invalid month format: %w", err)
-	}
-
-	prevMonth := t.AddDate(0, -1, 0).Format("2006-01")
-	
-	var total int64
-	err = db.QueryRow(
-		"SELECT COALESCE(SUM(amount), 0) FROM entries WHERE strftime('%Y-%m', date) = ? AND type = 'recurring'",
-		prevMonth,
-	).Scan(&total)
-	
-	return total, err
-}
-
-// GetAllEntries returns all entries (for TUI)
-func GetAllEntries() ([]models.Entry, error) {
-	return ListEntries("", "", "")
-}
-
 // parseDate parses various date formats from SQLite
 func parseDate(s string) time.Time {
 	// Try different formats
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"math"
+	"time"
+)
+
+// UnitEconomics are the per-customer economics of a month, from
+// subscription history and the acquisition spend entered for the month.
+// Amounts are in the reporting currency.
+type UnitEconomics struct {
+	Month            string
+	PayingCustomers  int   // Customers paying at the end of the month
+	Subscriptions    int   // Subscriptions active at the end of the month
+	SubscriptionMRR  int64 // MRR of those subscriptions
+	NewCustomers     int   // Customers whose first subscription started in the month
+	AcquisitionSpend int64
+	HasSpend         bool // Whether spend was entered for the month
+	ChurnMonths      int  // Trailing months churn is measured over
+	CustomerMonths   int  // Customers paying at the start of each of those months, summed
+	ChurnedCustomers int  // Of those, customers no longer paying at the month's end, summed
+}
+
+// ARPA returns the average MRR per paying customer (account)
+func (u *UnitEconomics) ARPA() int64 {
+	if u.PayingCustomers == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(u.SubscriptionMRR) / float64(u.PayingCustomers)))
+}
+
+// ARPU returns the average MRR per active subscription
+func (u *UnitEconomics) ARPU() int64 {
+	if u.Subscriptions == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(u.SubscriptionMRR) / float64(u.Subscriptions)))
+}
+
+// MonthlyChurnRate returns the average monthly logo churn over the
+// trailing months, 0 to 1
+func (u *UnitEconomics) MonthlyChurnRate() float64 {
+	if u.CustomerMonths == 0 {
+		return 0
+	}
+	return float64(u.ChurnedCustomers) / float64(u.CustomerMonths)
+}
+
+// Lifetime returns the expected customer lifetime in months, the inverse of
+// the monthly churn rate; 0 without churn, when it can't be estimated
+func (u *UnitEconomics) Lifetime() float64 {
+	churn := u.MonthlyChurnRate()
+	if churn == 0 {
+		return 0
+	}
+	return 1 / churn
+}
+
+// LTV returns the revenue a customer is expected to bring over their
+// lifetime; 0 when the lifetime can't be estimated
+func (u *UnitEconomics) LTV() int64 {
+	return int64(math.Round(float64(u.ARPA()) * u.Lifetime()))
+}
+
+// CAC returns the acquisition spend per new customer; 0 without spend or
+// new customers
+func (u *UnitEconomics) CAC() int64 {
+	if u.NewCustomers == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(u.AcquisitionSpend) / float64(u.NewCustomers)))
+}
+
+// PaybackMonths returns the months of ARPA it takes to earn back the CAC
+func (u *UnitEconomics) PaybackMonths() float64 {
+	if u.ARPA() == 0 {
+		return 0
+	}
+	return float64(u.CAC()) / float64(u.ARPA())
+}
+
+// GetUnitEconomics computes the unit economics of a month (YYYY-MM), with
+// churn averaged over the churnMonths trailing months up to and including it
+func GetUnitEconomics(s Store, month string, churnMonths int) (*UnitEconomics, error) {
+	monthEnd, err := monthEndDate(month)
+	if err != nil {
+		return nil, err
+	}
+	monthStart := time.Date(monthEnd.Year(), monthEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+	currency := s.ReportingCurrency()
+
+	u := &UnitEconomics{Month: month}
+
+	byCustomer, err := customerMRRAt(subs, fx, currency, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	for _, mrr := range byCustomer {
+		if mrr > 0 {
+			u.PayingCustomers++
+			u.SubscriptionMRR += mrr
+		}
+	}
+	for i := range subs {
+		if subs[i].IsActiveAt(monthEnd) {
+			u.Subscriptions++
+		}
+	}
+
+	for _, signup := range customerSignups(subs) {
+		if !signup.Before(monthStart) && !signup.After(monthEnd) {
+			u.NewCustomers++
+		}
+	}
+
+	spend, err := s.ListAcquisitionSpend()
+	if err != nil {
+		return nil, err
+	}
+	for _, sp := range spend {
+		if sp.Month != month {
+			continue
+		}
+		if u.AcquisitionSpend, err = fx.Convert(sp.Amount, sp.Currency, currency, month); err != nil {
+			return nil, err
+		}
+		u.HasSpend = true
+	}
+
+	u.ChurnMonths = churnMonths
+	for i := churnMonths - 1; i >= 0; i-- {
+		m := monthStart.AddDate(0, -i, 0).Format("2006-01")
+		r, err := GetRetention(s, m, m)
+		if err != nil {
+			return nil, err
+		}
+		u.CustomerMonths += r.StartCustomers
+		u.ChurnedCustomers += r.ChurnedCustomers
+	}
+
+	return u, nil
+}
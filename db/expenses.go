@@ -0,0 +1,177 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+const expenseColumns = "id, amount, currency, category, interval, note, date, end_date, created_at"
+
+// AddExpense adds a new expense
+func (s *SQLiteStore) AddExpense(amount int64, currency, category, interval, note string, date time.Time, endDate *time.Time) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO expenses (amount, currency, category, interval, note, date, end_date) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		amount, currency, category, interval, note, date.Format("2006-01-02"), nullableDate(endDate),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add expense: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetExpense retrieves a single expense by ID
+func (s *SQLiteStore) GetExpense(id int64) (*models.Expense, error) {
+	row := s.db.QueryRow("SELECT "+expenseColumns+" FROM expenses WHERE id = ?", id)
+
+	expense, err := scanExpense(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("expense not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense: %w", err)
+	}
+
+	return expense, nil
+}
+
+// ListExpenses lists expenses newest first, optionally of one category
+func (s *SQLiteStore) ListExpenses(category string) ([]models.Expense, error) {
+	query := "SELECT " + expenseColumns + " FROM expenses"
+	args := []interface{}{}
+	if category != "" {
+		query += " WHERE category = ?"
+		args = append(args, category)
+	}
+	query += " ORDER BY date DESC, id DESC"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []models.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense: %w", err)
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	return expenses, rows.Err()
+}
+
+// UpdateExpense updates the non-nil fields of an expense. A zero endDate
+// makes a recurring expense continue indefinitely.
+func (s *SQLiteStore) UpdateExpense(id int64, amount *int64, currency, category, interval, note *string, date, endDate *time.Time) error {
+	if _, err := s.GetExpense(id); err != nil {
+		return err
+	}
+
+	updates := []string{}
+	args := []interface{}{}
+
+	if amount != nil {
+		updates = append(updates, "amount = ?")
+		args = append(args, *amount)
+	}
+	if currency != nil {
+		updates = append(updates, "currency = ?")
+		args = append(args, *currency)
+	}
+	if category != nil {
+		updates = append(updates, "category = ?")
+		args = append(args, *category)
+	}
+	if interval != nil {
+		updates = append(updates, "interval = ?")
+		args = append(args, *interval)
+	}
+	if note != nil {
+		updates = append(updates, "note = ?")
+		args = append(args, *note)
+	}
+	if date != nil {
+		updates = append(updates, "date = ?")
+		args = append(args, date.Format("2006-01-02"))
+	}
+	if endDate != nil {
+		updates = append(updates, "end_date = ?")
+		if endDate.IsZero() {
+			args = append(args, nil)
+		} else {
+			args = append(args, endDate.Format("2006-01-02"))
+		}
+	}
+
+	if len(updates) == 0 {
+		return fmt.Errorf("no fields to update")
+	}
+
+	query := "UPDATE expenses SET "
+	for i, u := range updates {
+		if i > 0 {
+			query += ", "
+		}
+		query += u
+	}
+	query += " WHERE id = ?"
+	args = append(args, id)
+
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to update expense: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpense deletes an expense by ID
+func (s *SQLiteStore) DeleteExpense(id int64) error {
+	result, err := s.db.Exec("DELETE FROM expenses WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete expense: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("expense not found: %d", id)
+	}
+
+	return nil
+}
+
+func scanExpense(row rowScanner) (*models.Expense, error) {
+	var expense models.Expense
+	var dateStr string
+	var createdAtStr string
+	var endStr sql.NullString
+	var note sql.NullString
+
+	err := row.Scan(&expense.ID, &expense.Amount, &expense.Currency, &expense.Category, &expense.Interval,
+		&note, &dateStr, &endStr, &createdAtStr)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.Date = parseDate(dateStr)
+	expense.CreatedAt = parseDateTime(createdAtStr)
+	if endStr.Valid {
+		endDate := parseDate(endStr.String)
+		expense.EndDate = &endDate
+	}
+	expense.Note = note.String
+
+	return &expense, nil
+}
+
+// nullableDate maps a nil date to SQL NULL
+func nullableDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
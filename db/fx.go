@@ -0,0 +1,49 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// SetFXRate records the BaseCurrency value of one unit of currency for a month,
+// replacing any existing rate for that month
+func (s *SQLiteStore) SetFXRate(currency, month string, rate float64) error {
+	_, err := s.db.Exec(
+		"INSERT OR REPLACE INTO fx_rates (currency, month, rate) VALUES (?, ?, ?)",
+		currency, month, rate,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set FX rate: %w", err)
+	}
+	return nil
+}
+
+// ListFXRates lists all FX rates ordered by currency and month
+func (s *SQLiteStore) ListFXRates() ([]models.FXRate, error) {
+	rows, err := s.db.Query("SELECT currency, month, rate FROM fx_rates ORDER BY currency, month")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list FX rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []models.FXRate
+	for rows.Next() {
+		var r models.FXRate
+		if err := rows.Scan(&r.Currency, &r.Month, &r.Rate); err != nil {
+			return nil, fmt.Errorf("failed to scan FX rate: %w", err)
+		}
+		rates = append(rates, r)
+	}
+
+	return rates, rows.Err()
+}
+
+// LoadFXTable loads all FX rates into a conversion table
+func LoadFXTable(s Store) (*models.FXTable, error) {
+	rates, err := s.ListFXRates()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewFXTable(rates), nil
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// ImportEntry inserts or updates an entry by its import key
+func (s *SQLiteStore) ImportEntry(e *models.Entry) (ImportOutcome, error) {
+	if e.ImportKey == "" {
+		return 0, fmt.Errorf("failed to import entry: missing import key")
+	}
+
+	row := s.db.QueryRow("SELECT "+entryColumns+entryFrom+" WHERE e.import_key = ?", e.ImportKey)
+	existing, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		result, err := s.db.Exec(
+			"INSERT INTO entries (amount, currency, source, type, note, date, customer_id, import_key, origin) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			e.Amount, e.Currency, e.Source, e.Type, e.Note, e.Date.Format("2006-01-02"), nullableID(e.CustomerID), e.ImportKey, importOrigin(e.Origin),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import entry: %w", err)
+		}
+		e.ID, err = result.LastInsertId()
+		return ImportInserted, err
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up imported entry: %w", err)
+	}
+
+	e.ID = existing.ID
+	if sameImportedEntry(existing, e) {
+		return ImportDuplicate, nil
+	}
+
+	_, err = s.db.Exec(
+		"UPDATE entries SET amount = ?, currency = ?, source = ?, type = ?, note = ?, date = ?, customer_id = COALESCE(?, customer_id) WHERE id = ?",
+		e.Amount, e.Currency, e.Source, e.Type, e.Note, e.Date.Format("2006-01-02"), nullableID(e.CustomerID), e.ID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update imported entry: %w", err)
+	}
+	return ImportUpdated, nil
+}
+
+// ImportSubscription inserts or updates a subscription by its import key
+func (s *SQLiteStore) ImportSubscription(sub *models.Subscription) (ImportOutcome, error) {
+	if sub.ImportKey == "" {
+		return 0, fmt.Errorf("failed to import subscription: missing import key")
+	}
+
+	var cancelDate interface{}
+	if sub.CancelDate != nil {
+		if sub.CancelDate.Before(sub.StartDate) {
+			return 0, fmt.Errorf("cancel date %s is before start date %s", sub.CancelDate.Format("2006-01-02"), sub.StartDate.Format("2006-01-02"))
+		}
+		cancelDate = sub.CancelDate.Format("2006-01-02")
+	}
+
+	customerID, err := s.subscriptionCustomer(sub.Customer)
+	if err != nil {
+		return 0, err
+	}
+	sub.CustomerID = customerID
+
+	row := s.db.QueryRow("SELECT "+subscriptionColumns+" FROM subscriptions WHERE import_key = ?", sub.ImportKey)
+	existing, err := scanSubscription(row)
+	if err == sql.ErrNoRows {
+		result, err := s.db.Exec(
+			"INSERT INTO subscriptions (customer, customer_id, amount, currency, interval, source, note, start_date, cancel_date, import_key, origin) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			sub.Customer, nullableID(customerID), sub.Amount, sub.Currency, sub.Interval, sub.Source, sub.Note, sub.StartDate.Format("2006-01-02"), cancelDate, sub.ImportKey, importOrigin(sub.Origin),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import subscription: %w", err)
+		}
+		sub.ID, err = result.LastInsertId()
+		return ImportInserted, err
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up imported subscription: %w", err)
+	}
+
+	sub.ID = existing.ID
+	if sameImportedSubscription(existing, sub) {
+		return ImportDuplicate, nil
+	}
+
+	_, err = s.db.Exec(
+		"UPDATE subscriptions SET customer = ?, customer_id = ?, amount = ?, currency = ?, interval = ?, source = ?, note = ?, start_date = ?, cancel_date = ? WHERE id = ?",
+		sub.Customer, nullableID(customerID), sub.Amount, sub.Currency, sub.Interval, sub.Source, sub.Note, sub.StartDate.Format("2006-01-02"), cancelDate, sub.ID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update imported subscription: %w", err)
+	}
+	return ImportUpdated, nil
+}
+
+// DeleteImportedEntries deletes the entries imported under a key
+func (s *SQLiteStore) DeleteImportedEntries(key string) (int, error) {
+	if key == "" {
+		return 0, fmt.Errorf("failed to delete imported entries: missing import key")
+	}
+
+	spread := key + "#"
+	result, err := s.db.Exec(
+		"DELETE FROM entries WHERE import_key = ? OR substr(import_key, 1, ?) = ?",
+		key, len(spread), spread,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete imported entries: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
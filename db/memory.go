@@ -0,0 +1,793 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// MemoryStore is a Store that keeps everything in memory. It is safe for
+// concurrent use and behaves like SQLiteStore, including storing dates at
+// day precision.
+type MemoryStore struct {
+	currencySetting
+
+	mu            sync.Mutex
+	entries       []models.Entry
+	subscriptions []models.Subscription
+	customers     []models.Customer
+	fxRates       map[string]models.FXRate // keyed by currency and month
+	lastIDs       map[string]int64         // keyed by table, like AUTOINCREMENT
+	syncCursors   map[string]int64         // keyed by provider
+	apiTokens     []models.APIToken
+	spend         map[string]models.AcquisitionSpend // keyed by month
+	expenses      []models.Expense
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		fxRates:     make(map[string]models.FXRate),
+		lastIDs:     make(map[string]int64),
+		syncCursors: make(map[string]int64),
+		spend:       make(map[string]models.AcquisitionSpend),
+	}
+}
+
+// Close is a no-op; the data lives as long as the store
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// nextID returns a fresh ID for a table. Callers must hold m.mu.
+func (m *MemoryStore) nextID(table string) int64 {
+	m.lastIDs[table]++
+	return m.lastIDs[table]
+}
+
+// day truncates t to the calendar day, as SQLite DATE columns do
+func day(t time.Time) time.Time {
+	return parseDate(t.Format("2006-01-02"))
+}
+
+func (m *MemoryStore) AddEntry(amount int64, currency, source, entryType, note string, date time.Time, customerID int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := models.Entry{
+		ID:         m.nextID("entries"),
+		Amount:     amount,
+		Source:     source,
+		Type:       entryType,
+		Currency:   currency,
+		Note:       note,
+		Date:       day(date),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		CustomerID: customerID,
+		Origin:     models.OriginManual,
+	}
+	m.entries = append(m.entries, entry)
+
+	return entry.ID, nil
+}
+
+func (m *MemoryStore) GetEntry(id int64) (*models.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.entries {
+		if e.ID == id {
+			entry := m.withCustomerName(e)
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("entry not found: %d", id)
+}
+
+func (m *MemoryStore) ListEntries(month, source, entryType string, customerID int64) ([]models.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entries []models.Entry
+	for _, e := range m.entries {
+		if month != "" && e.Date.Format("2006-01") != month {
+			continue
+		}
+		if source != "" && e.Source != source {
+			continue
+		}
+		if entryType != "" && e.Type != entryType {
+			continue
+		}
+		if customerID != 0 && e.CustomerID != customerID {
+			continue
+		}
+		entries = append(entries, m.withCustomerName(e))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.After(entries[j].Date)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+
+	return entries, nil
+}
+
+func (m *MemoryStore) UpdateEntry(id int64, amount *int64, currency, source, note *string, customerID *int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entry *models.Entry
+	for i := range m.entries {
+		if m.entries[i].ID == id {
+			entry = &m.entries[i]
+		}
+	}
+	if entry == nil {
+		return fmt.Errorf("entry not found: %d", id)
+	}
+
+	if amount == nil && currency == nil && source == nil && note == nil && customerID == nil {
+		return fmt.Errorf("no fields to update")
+	}
+
+	if amount != nil {
+		entry.Amount = *amount
+	}
+	if currency != nil {
+		entry.Currency = *currency
+	}
+	if source != nil {
+		entry.Source = *source
+	}
+	if note != nil {
+		entry.Note = *note
+	}
+	if customerID != nil {
+		entry.CustomerID = *customerID
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteEntry(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, e := range m.entries {
+		if e.ID == id {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("entry not found: %d", id)
+}
+
+// withCustomerName fills in the linked customer's name, like the SQL join
+func (m *MemoryStore) withCustomerName(e models.Entry) models.Entry {
+	e.CustomerName = ""
+	if c := m.findCustomer(e.CustomerID); c != nil {
+		e.CustomerName = c.Name
+	}
+	return e
+}
+
+func (m *MemoryStore) AddSubscription(customer string, amount int64, currency, interval, source, note string, startDate time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := models.Subscription{
+		ID:         m.nextID("subscriptions"),
+		Customer:   customer,
+		CustomerID: m.subscriptionCustomer(customer),
+		Amount:     amount,
+		Interval:   interval,
+		Source:     source,
+		Currency:   currency,
+		StartDate:  day(startDate),
+		Note:       note,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		Origin:     models.OriginManual,
+	}
+	m.subscriptions = append(m.subscriptions, sub)
+
+	return sub.ID, nil
+}
+
+func (m *MemoryStore) GetSubscription(id int64) (*models.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.subscriptions {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("subscription not found: %d", id)
+}
+
+func (m *MemoryStore) ListSubscriptions(activeOnly bool) ([]models.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var subs []models.Subscription
+	for _, s := range m.subscriptions {
+		if activeOnly && s.CancelDate != nil {
+			continue
+		}
+		subs = append(subs, s)
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].StartDate.Equal(subs[j].StartDate) {
+			return subs[i].StartDate.After(subs[j].StartDate)
+		}
+		return subs[i].ID > subs[j].ID
+	})
+
+	return subs, nil
+}
+
+func (m *MemoryStore) ListActiveSubscriptions(at time.Time) ([]models.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	at = day(at)
+
+	var subs []models.Subscription
+	for _, s := range m.subscriptions {
+		if s.StartDate.After(at) {
+			continue
+		}
+		if s.CancelDate != nil && !s.CancelDate.After(at) {
+			continue
+		}
+		subs = append(subs, s)
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].StartDate.Equal(subs[j].StartDate) {
+			return subs[i].StartDate.Before(subs[j].StartDate)
+		}
+		return subs[i].ID < subs[j].ID
+	})
+
+	return subs, nil
+}
+
+func (m *MemoryStore) CancelSubscription(id int64, cancelDate time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.subscriptions {
+		sub := &m.subscriptions[i]
+		if sub.ID != id {
+			continue
+		}
+		if err := checkCancel(sub, cancelDate); err != nil {
+			return err
+		}
+		d := day(cancelDate)
+		sub.CancelDate = &d
+		return nil
+	}
+	return fmt.Errorf("subscription not found: %d", id)
+}
+
+func (m *MemoryStore) AddCustomer(name, email, note string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.customers {
+		if c.Name == name {
+			return 0, fmt.Errorf("customer already exists: %s", name)
+		}
+	}
+
+	customer := models.Customer{
+		ID:        m.nextID("customers"),
+		Name:      name,
+		Email:     email,
+		Note:      note,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	m.customers = append(m.customers, customer)
+
+	return customer.ID, nil
+}
+
+// subscriptionCustomer is SQLiteStore.subscriptionCustomer. Callers must
+// hold m.mu.
+func (m *MemoryStore) subscriptionCustomer(name string) int64 {
+	if name == "" {
+		return 0
+	}
+	for _, c := range m.customers {
+		if c.Name == name {
+			return c.ID
+		}
+	}
+
+	customer := models.Customer{
+		ID:        m.nextID("customers"),
+		Name:      name,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	m.customers = append(m.customers, customer)
+	return customer.ID
+}
+
+func (m *MemoryStore) GetCustomer(id int64) (*models.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c := m.findCustomer(id); c != nil {
+		customer := *c
+		return &customer, nil
+	}
+	return nil, fmt.Errorf("customer not found: %d", id)
+}
+
+func (m *MemoryStore) GetCustomerByName(name string) (*models.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.customers {
+		if c.Name == name {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("customer not found: %s", name)
+}
+
+func (m *MemoryStore) ListCustomers() ([]models.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	customers := append([]models.Customer(nil), m.customers...)
+	sort.Slice(customers, func(i, j int) bool {
+		return customers[i].Name < customers[j].Name
+	})
+
+	return customers, nil
+}
+
+// findCustomer returns the customer with the given ID, or nil. Callers must
+// hold m.mu.
+func (m *MemoryStore) findCustomer(id int64) *models.Customer {
+	if id == 0 {
+		return nil
+	}
+	for i := range m.customers {
+		if m.customers[i].ID == id {
+			return &m.customers[i]
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) SetFXRate(currency, month string, rate float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.fxRates[currency+"/"+month] = models.FXRate{Currency: currency, Month: month, Rate: rate}
+	return nil
+}
+
+func (m *MemoryStore) ListFXRates() ([]models.FXRate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rates []models.FXRate
+	for _, r := range m.fxRates {
+		rates = append(rates, r)
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return rates[i].Month < rates[j].Month
+	})
+
+	return rates, nil
+}
+
+func (m *MemoryStore) ImportEntry(e *models.Entry) (ImportOutcome, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e.ImportKey == "" {
+		return 0, fmt.Errorf("failed to import entry: missing import key")
+	}
+
+	for i := range m.entries {
+		existing := &m.entries[i]
+		if existing.ImportKey != e.ImportKey {
+			continue
+		}
+
+		e.ID = existing.ID
+		if sameImportedEntry(existing, e) {
+			return ImportDuplicate, nil
+		}
+
+		existing.Amount = e.Amount
+		existing.Currency = e.Currency
+		existing.Source = e.Source
+		existing.Type = e.Type
+		existing.Note = e.Note
+		existing.Date = day(e.Date)
+		if e.CustomerID != 0 {
+			existing.CustomerID = e.CustomerID
+		}
+		return ImportUpdated, nil
+	}
+
+	entry := *e
+	entry.ID = m.nextID("entries")
+	entry.Date = day(e.Date)
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	entry.CustomerName = ""
+	entry.Origin = importOrigin(e.Origin)
+	m.entries = append(m.entries, entry)
+
+	e.ID = entry.ID
+	return ImportInserted, nil
+}
+
+func (m *MemoryStore) ImportSubscription(sub *models.Subscription) (ImportOutcome, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sub.ImportKey == "" {
+		return 0, fmt.Errorf("failed to import subscription: missing import key")
+	}
+
+	var cancelDate *time.Time
+	if sub.CancelDate != nil {
+		if sub.CancelDate.Before(sub.StartDate) {
+			return 0, fmt.Errorf("cancel date %s is before start date %s", sub.CancelDate.Format("2006-01-02"), sub.StartDate.Format("2006-01-02"))
+		}
+		d := day(*sub.CancelDate)
+		cancelDate = &d
+	}
+	sub.CustomerID = m.subscriptionCustomer(sub.Customer)
+
+	for i := range m.subscriptions {
+		existing := &m.subscriptions[i]
+		if existing.ImportKey != sub.ImportKey {
+			continue
+		}
+
+		sub.ID = existing.ID
+		if sameImportedSubscription(existing, sub) {
+			return ImportDuplicate, nil
+		}
+
+		existing.Customer = sub.Customer
+		existing.CustomerID = sub.CustomerID
+		existing.Amount = sub.Amount
+		existing.Currency = sub.Currency
+		existing.Interval = sub.Interval
+		existing.Source = sub.Source
+		existing.Note = sub.Note
+		existing.StartDate = day(sub.StartDate)
+		existing.CancelDate = cancelDate
+		return ImportUpdated, nil
+	}
+
+	s := *sub
+	s.ID = m.nextID("subscriptions")
+	s.StartDate = day(sub.StartDate)
+	s.CancelDate = cancelDate
+	s.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	s.Origin = importOrigin(sub.Origin)
+	m.subscriptions = append(m.subscriptions, s)
+
+	sub.ID = s.ID
+	return ImportInserted, nil
+}
+
+func (m *MemoryStore) DeleteImportedEntries(key string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key == "" {
+		return 0, fmt.Errorf("failed to delete imported entries: missing import key")
+	}
+
+	kept := make([]models.Entry, 0, len(m.entries))
+	for _, e := range m.entries {
+		if e.ImportKey != key && !strings.HasPrefix(e.ImportKey, key+"#") {
+			kept = append(kept, e)
+		}
+	}
+	deleted := len(m.entries) - len(kept)
+	m.entries = kept
+	return deleted, nil
+}
+
+// Transaction runs fn against the store itself, restoring a snapshot of the
+// data if fn returns an error. Writes by other goroutines during fn are
+// discarded along with fn's on rollback.
+func (m *MemoryStore) Transaction(fn func(tx Store) error) error {
+	m.mu.Lock()
+	entries := append([]models.Entry(nil), m.entries...)
+	subscriptions := append([]models.Subscription(nil), m.subscriptions...)
+	customers := append([]models.Customer(nil), m.customers...)
+	apiTokens := append([]models.APIToken(nil), m.apiTokens...)
+	expenses := append([]models.Expense(nil), m.expenses...)
+	fxRates := make(map[string]models.FXRate, len(m.fxRates))
+	for k, v := range m.fxRates {
+		fxRates[k] = v
+	}
+	lastIDs := make(map[string]int64, len(m.lastIDs))
+	for k, v := range m.lastIDs {
+		lastIDs[k] = v
+	}
+	syncCursors := make(map[string]int64, len(m.syncCursors))
+	for k, v := range m.syncCursors {
+		syncCursors[k] = v
+	}
+	spend := make(map[string]models.AcquisitionSpend, len(m.spend))
+	for k, v := range m.spend {
+		spend[k] = v
+	}
+	m.mu.Unlock()
+
+	if err := fn(m); err != nil {
+		m.mu.Lock()
+		m.entries, m.subscriptions, m.customers, m.apiTokens = entries, subscriptions, customers, apiTokens
+		m.fxRates, m.lastIDs, m.syncCursors, m.spend = fxRates, lastIDs, syncCursors, spend
+		m.expenses = expenses
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (m *MemoryStore) AddExpense(amount int64, currency, category, interval, note string, date time.Time, endDate *time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expense := models.Expense{
+		ID:        m.nextID("expenses"),
+		Amount:    amount,
+		Currency:  currency,
+		Category:  category,
+		Interval:  interval,
+		Note:      note,
+		Date:      day(date),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if endDate != nil {
+		end := day(*endDate)
+		expense.EndDate = &end
+	}
+	m.expenses = append(m.expenses, expense)
+
+	return expense.ID, nil
+}
+
+func (m *MemoryStore) GetExpense(id int64) (*models.Expense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.expenses {
+		if e.ID == id {
+			expense := e
+			return &expense, nil
+		}
+	}
+	return nil, fmt.Errorf("expense not found: %d", id)
+}
+
+func (m *MemoryStore) ListExpenses(category string) ([]models.Expense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expenses []models.Expense
+	for _, e := range m.expenses {
+		if category != "" && e.Category != category {
+			continue
+		}
+		expenses = append(expenses, e)
+	}
+
+	sort.Slice(expenses, func(i, j int) bool {
+		if !expenses[i].Date.Equal(expenses[j].Date) {
+			return expenses[i].Date.After(expenses[j].Date)
+		}
+		return expenses[i].ID > expenses[j].ID
+	})
+
+	return expenses, nil
+}
+
+func (m *MemoryStore) UpdateExpense(id int64, amount *int64, currency, category, interval, note *string, date, endDate *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expense *models.Expense
+	for i := range m.expenses {
+		if m.expenses[i].ID == id {
+			expense = &m.expenses[i]
+		}
+	}
+	if expense == nil {
+		return fmt.Errorf("expense not found: %d", id)
+	}
+
+	if amount == nil && currency == nil && category == nil && interval == nil && note == nil && date == nil && endDate == nil {
+		return fmt.Errorf("no fields to update")
+	}
+
+	if amount != nil {
+		expense.Amount = *amount
+	}
+	if currency != nil {
+		expense.Currency = *currency
+	}
+	if category != nil {
+		expense.Category = *category
+	}
+	if interval != nil {
+		expense.Interval = *interval
+	}
+	if note != nil {
+		expense.Note = *note
+	}
+	if date != nil {
+		expense.Date = day(*date)
+	}
+	if endDate != nil {
+		if endDate.IsZero() {
+			expense.EndDate = nil
+		} else {
+			end := day(*endDate)
+			expense.EndDate = &end
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteExpense(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, e := range m.expenses {
+		if e.ID == id {
+			m.expenses = append(m.expenses[:i], m.expenses[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("expense not found: %d", id)
+}
+
+func (m *MemoryStore) SetAcquisitionSpend(month string, amount int64, currency, note string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spend[month] = models.AcquisitionSpend{Month: month, Amount: amount, Currency: currency, Note: note}
+	return nil
+}
+
+func (m *MemoryStore) ListAcquisitionSpend() ([]models.AcquisitionSpend, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var spend []models.AcquisitionSpend
+	for _, sp := range m.spend {
+		spend = append(spend, sp)
+	}
+
+	sort.Slice(spend, func(i, j int) bool {
+		return spend[i].Month < spend[j].Month
+	})
+
+	return spend, nil
+}
+
+func (m *MemoryStore) DeleteAcquisitionSpend(month string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.spend[month]; !ok {
+		return fmt.Errorf("no acquisition spend for %s", month)
+	}
+	delete(m.spend, month)
+	return nil
+}
+
+func (m *MemoryStore) GetSyncCursor(provider string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.syncCursors[provider], nil
+}
+
+func (m *MemoryStore) SetSyncCursor(provider string, cursor int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.syncCursors[provider] = cursor
+	return nil
+}
+
+func (m *MemoryStore) AddAPIToken(name, scope, hash, prefix string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range m.apiTokens {
+		if t.Name == name {
+			return 0, fmt.Errorf("token already exists: %s", name)
+		}
+	}
+
+	token := models.APIToken{
+		ID:        m.nextID("api_tokens"),
+		Name:      name,
+		Scope:     scope,
+		Hash:      hash,
+		Prefix:    prefix,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	m.apiTokens = append(m.apiTokens, token)
+
+	return token.ID, nil
+}
+
+func (m *MemoryStore) GetAPITokenByHash(hash string) (*models.APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range m.apiTokens {
+		if t.Hash == hash {
+			token := t
+			return &token, nil
+		}
+	}
+	return nil, fmt.Errorf("token not found")
+}
+
+func (m *MemoryStore) ListAPITokens() ([]models.APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]models.APIToken(nil), m.apiTokens...), nil
+}
+
+func (m *MemoryStore) TouchAPIToken(id int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.apiTokens {
+		if m.apiTokens[i].ID == id {
+			lastUsed := at.UTC().Truncate(time.Second)
+			m.apiTokens[i].LastUsedAt = &lastUsed
+			return nil
+		}
+	}
+	return fmt.Errorf("token not found: %d", id)
+}
+
+func (m *MemoryStore) DeleteAPIToken(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, t := range m.apiTokens {
+		if t.ID == id {
+			m.apiTokens = append(m.apiTokens[:i], m.apiTokens[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("token not found: %d", id)
+}
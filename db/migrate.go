@@ -0,0 +1,324 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration is a single ordered schema change. Migrations must tolerate
+// databases created by releases that predate schema versioning, which is
+// why they use IF NOT EXISTS and addColumn rather than bare DDL.
+type migration struct {
+	version     int
+	description string
+	up          func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change in order. Append only: never edit or
+// reorder a migration that has shipped.
+var migrations = []migration{
+	{1, "create entries table", execSQL(`
+		CREATE TABLE IF NOT EXISTS entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			amount INTEGER NOT NULL,
+			source TEXT NOT NULL DEFAULT 'manual',
+			type TEXT NOT NULL DEFAULT 'recurring',
+			note TEXT,
+			date DATE NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_entries_date ON entries(date);
+		CREATE INDEX IF NOT EXISTS idx_entries_source ON entries(source);
+	`)},
+	{2, "create subscriptions table", execSQL(`
+		CREATE TABLE IF NOT EXISTS subscriptions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			customer TEXT NOT NULL,
+			amount INTEGER NOT NULL,
+			interval TEXT NOT NULL DEFAULT 'month',
+			source TEXT NOT NULL DEFAULT 'manual',
+			start_date DATE NOT NULL,
+			cancel_date DATE,
+			note TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_subscriptions_start ON subscriptions(start_date);
+	`)},
+	{3, "create customers table and link entries", func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS customers (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE,
+				email TEXT,
+				note TEXT,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+		if err := addColumn(tx, "entries", "customer_id", "INTEGER REFERENCES customers(id)"); err != nil {
+			return err
+		}
+		_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_entries_customer ON entries(customer_id)")
+		return err
+	}},
+	{4, "add currencies and fx_rates table", func(tx *sql.Tx) error {
+		if err := addColumn(tx, "entries", "currency", "TEXT NOT NULL DEFAULT 'USD'"); err != nil {
+			return err
+		}
+		if err := addColumn(tx, "subscriptions", "currency", "TEXT NOT NULL DEFAULT 'USD'"); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS fx_rates (
+				currency TEXT NOT NULL,
+				month TEXT NOT NULL,
+				rate REAL NOT NULL,
+				PRIMARY KEY (currency, month)
+			);
+		`)
+		return err
+	}},
+	{5, "add import keys for idempotent imports", func(tx *sql.Tx) error {
+		if err := addColumn(tx, "entries", "import_key", "TEXT"); err != nil {
+			return err
+		}
+		if err := addColumn(tx, "subscriptions", "import_key", "TEXT"); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_entries_import_key ON entries(import_key);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_subscriptions_import_key ON subscriptions(import_key);
+		`)
+		return err
+	}},
+	{6, "add origins and sync cursors", func(tx *sql.Tx) error {
+		for _, table := range []string{"entries", "subscriptions"} {
+			if err := addColumn(tx, table, "origin", "TEXT NOT NULL DEFAULT 'manual'"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("UPDATE " + table + " SET origin = 'import' WHERE import_key IS NOT NULL"); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS sync_cursors (
+				provider TEXT PRIMARY KEY,
+				cursor INTEGER NOT NULL,
+				synced_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		return err
+	}},
+	{7, "add API tokens", func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS api_tokens (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE,
+				scope TEXT NOT NULL,
+				token_hash TEXT NOT NULL UNIQUE,
+				prefix TEXT NOT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				last_used_at DATETIME
+			);
+		`)
+		return err
+	}},
+	{8, "add acquisition spend", execSQL(`
+		CREATE TABLE IF NOT EXISTS acquisition_spend (
+			month TEXT PRIMARY KEY,
+			amount INTEGER NOT NULL,
+			currency TEXT NOT NULL DEFAULT 'USD',
+			note TEXT
+		);
+	`)},
+	{9, "create expenses table", execSQL(`
+		CREATE TABLE IF NOT EXISTS expenses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			amount INTEGER NOT NULL,
+			currency TEXT NOT NULL DEFAULT 'USD',
+			category TEXT NOT NULL DEFAULT 'other',
+			interval TEXT NOT NULL DEFAULT 'once',
+			note TEXT,
+			date DATE NOT NULL,
+			end_date DATE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_expenses_date ON expenses(date);
+	`)},
+	{10, "link subscriptions to customers", func(tx *sql.Tx) error {
+		if err := addColumn(tx, "subscriptions", "customer_id", "INTEGER REFERENCES customers(id)"); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`
+			INSERT OR IGNORE INTO customers (name)
+				SELECT DISTINCT customer FROM subscriptions WHERE customer <> '';
+			UPDATE subscriptions SET customer_id = (SELECT id FROM customers WHERE name = subscriptions.customer)
+				WHERE customer_id IS NULL;
+			CREATE INDEX IF NOT EXISTS idx_subscriptions_customer ON subscriptions(customer_id);
+		`)
+		return err
+	}},
+}
+
+// MigrationStatus describes a known migration and whether it has been applied
+type MigrationStatus struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time // nil if pending
+}
+
+// LatestSchemaVersion returns the schema version this binary migrates to
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// SchemaVersion returns the database's current schema version (0 if unversioned)
+func (s *SQLiteStore) SchemaVersion() (int, error) {
+	if err := s.ensureVersionTable(); err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Migrate applies all pending migrations in a single transaction and returns
+// the ones it applied. It refuses to touch a database whose schema is newer
+// than this binary knows about.
+func (s *SQLiteStore) Migrate() ([]MigrationStatus, error) {
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := LatestSchemaVersion()
+	if current > latest {
+		return nil, fmt.Errorf("database schema version %d is newer than this version of mrr supports (%d); please upgrade mrr", current, latest)
+	}
+	if current == latest {
+		return nil, nil
+	}
+
+	if s.conn == nil {
+		return nil, fmt.Errorf("cannot migrate inside a transaction")
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	var applied []MigrationStatus
+	now := time.Now()
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := m.up(tx); err != nil {
+			return nil, fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO schema_version (version, description) VALUES (?, ?)",
+			m.version, m.description,
+		); err != nil {
+			return nil, fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		applied = append(applied, MigrationStatus{Version: m.version, Description: m.description, AppliedAt: &now})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// GetMigrationStatus lists every known migration with its applied time
+func (s *SQLiteStore) GetMigrationStatus() ([]MigrationStatus, error) {
+	if err := s.ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query("SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at string
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan schema version: %w", err)
+		}
+		appliedAt[version] = parseDateTime(at)
+	}
+
+	var status []MigrationStatus
+	for _, m := range migrations {
+		ms := MigrationStatus{Version: m.version, Description: m.description}
+		if at, ok := appliedAt[m.version]; ok {
+			ms.AppliedAt = &at
+		}
+		status = append(status, ms)
+	}
+
+	return status, nil
+}
+
+func (s *SQLiteStore) ensureVersionTable() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	return nil
+}
+
+// execSQL returns a migration step that executes a block of SQL
+func execSQL(query string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(query)
+		return err
+	}
+}
+
+// addColumn adds a column to a table unless it already exists
+func addColumn(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	rows.Close()
+
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s.%s: %w", table, column, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// MRRMovement is the MRR bridge for a month: how MRR moved from the end of
+// the previous month to the end of this one. Contraction and Churn are
+// stored as positive amounts. Customers' MRR is compared at this month's
+// exchange rates; FX is what re-valuing the previous month's subscriptions at
+// them changed.
+type MRRMovement struct {
+	Month            string
+	StartMRR         int64
+	New              int64
+	Expansion        int64
+	Contraction      int64
+	Churn            int64
+	Reactivation     int64
+	Adjustment       int64 // Change in manual recurring entries vs previous month
+	FX               int64 // Change in subscription MRR from exchange rates
+	EndMRR           int64
+	NewCustomers     int
+	ChurnedCustomers int
+}
+
+// NetNew returns the net MRR change for the month
+func (m *MRRMovement) NetNew() int64 {
+	return m.New + m.Expansion + m.Reactivation - m.Contraction - m.Churn + m.Adjustment + m.FX
+}
+
+// GetMRRMovements computes the MRR bridge for every month from..to (YYYY-MM, inclusive)
+func GetMRRMovements(s Store, from, to string) ([]MRRMovement, error) {
+	start, err := time.Parse("2006-01", from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format: %w", err)
+	}
+	end, err := time.Parse("2006-01", to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end month %s is before start month %s", to, from)
+	}
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var movements []MRRMovement
+	for t := start; !t.After(end); t = t.AddDate(0, 1, 0) {
+		m, err := computeMovement(s, subs, fx, t.Format("2006-01"))
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, *m)
+	}
+
+	return movements, nil
+}
+
+// GetMRRMovement computes the MRR bridge for a single month
+func GetMRRMovement(s Store, month string) (*MRRMovement, error) {
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeMovement(s, subs, fx, month)
+}
+
+func computeMovement(s Store, subs []models.Subscription, fx *models.FXTable, month string) (*MRRMovement, error) {
+	monthEnd, err := monthEndDate(month)
+	if err != nil {
+		return nil, err
+	}
+	prevEnd := monthEnd.AddDate(0, 0, -monthEnd.Day())
+	prevMonth := prevEnd.Format("2006-01")
+
+	m := &MRRMovement{Month: month}
+
+	// Last month's MRR at its own rates, to tell exchange rate moves apart
+	prevAtPrevRates, err := customerMRRAt(subs, fx, s.ReportingCurrency(), prevEnd)
+	if err != nil {
+		return nil, err
+	}
+	prevByCustomer, err := customerMRRAtRates(subs, fx, s.ReportingCurrency(), prevEnd, month)
+	if err != nil {
+		return nil, err
+	}
+	curByCustomer, err := customerMRRAt(subs, fx, s.ReportingCurrency(), monthEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	for customer, cur := range curByCustomer {
+		prev := prevByCustomer[customer]
+		switch {
+		case prev == 0 && hadSubscriptionBy(subs, customer, prevEnd):
+			m.Reactivation += cur
+		case prev == 0:
+			m.New += cur
+			m.NewCustomers++
+		case cur > prev:
+			m.Expansion += cur - prev
+		case cur < prev:
+			m.Contraction += prev - cur
+		}
+	}
+
+	for customer, prev := range prevByCustomer {
+		if curByCustomer[customer] == 0 {
+			m.Churn += prev
+			m.ChurnedCustomers++
+		}
+	}
+
+	if m.StartMRR, err = GetMonthMRR(s, prevMonth); err != nil {
+		return nil, err
+	}
+	if m.EndMRR, err = GetMonthMRR(s, month); err != nil {
+		return nil, err
+	}
+
+	// Whatever subscriptions don't explain comes from manual recurring entries
+	var subStart, subRevalued, subEnd int64
+	for _, v := range prevAtPrevRates {
+		subStart += v
+	}
+	for _, v := range prevByCustomer {
+		subRevalued += v
+	}
+	for _, v := range curByCustomer {
+		subEnd += v
+	}
+	m.FX = subRevalued - subStart
+	m.Adjustment = (m.EndMRR - subEnd) - (m.StartMRR - subStart)
+
+	return m, nil
+}
+
+// customerMRRAt sums the MRR of each customer's subscriptions active on day t,
+// converted into currency
+func customerMRRAt(subs []models.Subscription, fx *models.FXTable, currency string, t time.Time) (map[string]int64, error) {
+	return customerMRRAtRates(subs, fx, currency, t, t.Format("2006-01"))
+}
+
+// customerMRRAtRates is customerMRRAt converting at month's rates, so MRR on
+// days in different months can be compared without exchange rate moves
+// showing up as expansion or contraction
+func customerMRRAtRates(subs []models.Subscription, fx *models.FXTable, currency string, t time.Time, month string) (map[string]int64, error) {
+	mrr := make(map[string]int64)
+	for i := range subs {
+		if !subs[i].IsActiveAt(t) {
+			continue
+		}
+		amount, err := fx.Convert(subs[i].MonthlyAmount(), subs[i].Currency, currency, month)
+		if err != nil {
+			return nil, err
+		}
+		mrr[subs[i].Customer] += amount
+	}
+	return mrr, nil
+}
+
+// hadSubscriptionBy reports whether a customer had any subscription started on or before t
+func hadSubscriptionBy(subs []models.Subscription, customer string, t time.Time) bool {
+	for _, s := range subs {
+		if s.Customer == customer && !s.StartDate.After(t) {
+			return true
+		}
+	}
+	return false
+}
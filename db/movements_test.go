@@ -0,0 +1,43 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestMovementsSeparateExchangeRates(t *testing.T) {
+	s := NewMemoryStore()
+	if _, err := s.AddSubscription("acme", 10000, "EUR", "month", "stripe", "", date("2026-01-15")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetFXRate("EUR", "2026-01", 1.10); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetFXRate("EUR", "2026-02", 1.20); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := GetMRRMovement(s, "2026-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Expansion != 0 || m.Contraction != 0 {
+		t.Errorf("constant EUR price moved: expansion %d, contraction %d, want 0", m.Expansion, m.Contraction)
+	}
+	if m.FX != 1000 {
+		t.Errorf("FX = %d, want 1000", m.FX)
+	}
+	if m.Adjustment != 0 {
+		t.Errorf("adjustment = %d, want 0", m.Adjustment)
+	}
+	if m.StartMRR+m.NetNew() != m.EndMRR {
+		t.Errorf("bridge doesn't add up: %d + %d != %d", m.StartMRR, m.NetNew(), m.EndMRR)
+	}
+
+	r, err := GetRetention(s, "2026-02", "2026-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ExpansionMRR != 0 || r.ContractionMRR != 0 {
+		t.Errorf("retention: expansion %d, contraction %d, want 0", r.ExpansionMRR, r.ContractionMRR)
+	}
+}
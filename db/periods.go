@@ -0,0 +1,203 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Report period granularities
+const (
+	PeriodWeek    = "week"    // ISO weeks, Monday to Sunday
+	PeriodMonth   = "month"   // Calendar months
+	PeriodQuarter = "quarter" // Calendar quarters
+	PeriodYear    = "year"    // Calendar years
+)
+
+// ValidPeriods contains all valid report period granularities
+var ValidPeriods = []string{PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear}
+
+// IsValidPeriod checks if a report period granularity is valid
+func IsValidPeriod(period string) bool {
+	for _, p := range ValidPeriods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// PeriodStart returns the first day of the period holding t
+func PeriodStart(t time.Time, period string) time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case PeriodWeek:
+		return d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+	case PeriodQuarter:
+		return time.Date(d.Year(), (d.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case PeriodYear:
+		return time.Date(d.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// NextPeriod returns the first day of the period after the one starting on start
+func NextPeriod(start time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodQuarter:
+		return start.AddDate(0, 3, 0)
+	case PeriodYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// PeriodLabel names the period starting on start: 2026-W41, 2026-10,
+// 2026-Q4 or 2026
+func PeriodLabel(start time.Time, period string) string {
+	switch period {
+	case PeriodWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	case PeriodYear:
+		return start.Format("2006")
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+// PeriodReport summarizes one period of a time series. MRR is measured at
+// the end of the period like GetMonthlyReport measures it at the end of a
+// month; QuarterAgoMRR and YearAgoMRR are the MRR three months and a year
+// before that.
+type PeriodReport struct {
+	Period            string // Label, see PeriodLabel
+	Start             time.Time
+	End               time.Time // Last day of the period
+	MRR               int64
+	PrevMRR           int64 // MRR at the end of the period before
+	QuarterAgoMRR     int64
+	YearAgoMRR        int64
+	OneTimeRevenue    int64 // One-time entries dated in the period
+	EntryCount        int   // Entries dated in the period
+	SubscriptionCount int   // Subscriptions active at the end of the period
+}
+
+// GetPeriodReports reports on every period of the given granularity from
+// the one holding from through the one holding to
+func GetPeriodReports(s Store, from, to time.Time, period string) ([]PeriodReport, error) {
+	if !IsValidPeriod(period) {
+		return nil, fmt.Errorf("invalid period: %s (valid: %v)", period, ValidPeriods)
+	}
+	start := PeriodStart(from, period)
+	last := PeriodStart(to, period)
+	if last.Before(start) {
+		return nil, fmt.Errorf("end %s is before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	entries, err := s.ListEntries("", "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+	currency := s.ReportingCurrency()
+
+	var reports []PeriodReport
+	for t := start; !t.After(last); t = NextPeriod(t, period) {
+		end := NextPeriod(t, period).AddDate(0, 0, -1)
+		r := PeriodReport{Period: PeriodLabel(t, period), Start: t, End: end}
+
+		for _, e := range entries {
+			if e.Date.Before(t) || e.Date.After(end) {
+				continue
+			}
+			r.EntryCount++
+			if e.Type != "recurring" {
+				amount, err := fx.Convert(e.Amount, e.Currency, currency, e.Date.Format("2006-01"))
+				if err != nil {
+					return nil, err
+				}
+				r.OneTimeRevenue += amount
+			}
+		}
+		for i := range subs {
+			if subs[i].IsActiveAt(end) {
+				r.SubscriptionCount++
+			}
+		}
+
+		for _, m := range []struct {
+			mrr *int64
+			at  time.Time
+		}{
+			{&r.MRR, end},
+			{&r.PrevMRR, t.AddDate(0, 0, -1)},
+			{&r.QuarterAgoMRR, monthsBefore(end, 3)},
+			{&r.YearAgoMRR, monthsBefore(end, 12)},
+		} {
+			if *m.mrr, err = mrrAt(subs, entries, fx, currency, m.at); err != nil {
+				return nil, err
+			}
+		}
+
+		reports = append(reports, r)
+	}
+
+	return reports, nil
+}
+
+// monthsBefore returns the day n months before t. The last day of a month
+// maps to the last day of the earlier month, and days the earlier month
+// doesn't have to its last day, so a comparison never spills into the
+// month after.
+func monthsBefore(t time.Time, n int) time.Time {
+	last := time.Date(t.Year(), t.Month()-time.Month(n)+1, 0, 0, 0, 0, 0, time.UTC)
+	if t.AddDate(0, 0, 1).Day() == 1 || t.Day() > last.Day() {
+		return last
+	}
+	return time.Date(t.Year(), t.Month()-time.Month(n), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// mrrAt returns the MRR on day t: the subscriptions active then, plus the
+// recurring entries dated in t's month up to t as manual adjustments. At the
+// end of a month it matches GetMonthlyReport.
+func mrrAt(subs []models.Subscription, entries []models.Entry, fx *models.FXTable, currency string, t time.Time) (int64, error) {
+	month := t.Format("2006-01")
+
+	var mrr int64
+	for i := range subs {
+		if !subs[i].IsActiveAt(t) {
+			continue
+		}
+		amount, err := fx.Convert(subs[i].MonthlyAmount(), subs[i].Currency, currency, month)
+		if err != nil {
+			return 0, err
+		}
+		mrr += amount
+	}
+	for _, e := range entries {
+		if e.Type != "recurring" || e.Date.Format("2006-01") != month || e.Date.After(t) {
+			continue
+		}
+		amount, err := fx.Convert(e.Amount, e.Currency, currency, month)
+		if err != nil {
+			return 0, err
+		}
+		mrr += amount
+	}
+	return mrr, nil
+}
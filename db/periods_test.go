@@ -0,0 +1,79 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestMonthsBefore(t *testing.T) {
+	tests := []struct {
+		t    string
+		n    int
+		want string
+	}{
+		{"2025-12-31", 3, "2025-09-30"},
+		{"2025-05-31", 3, "2025-02-28"},
+		{"2024-05-31", 3, "2024-02-29"},
+		{"2025-07-31", 3, "2025-04-30"},
+		{"2025-02-28", 3, "2024-11-30"},
+		{"2025-12-31", 12, "2024-12-31"},
+		{"2024-02-29", 12, "2023-02-28"},
+		{"2025-08-30", 6, "2025-02-28"},
+		{"2025-10-12", 3, "2025-07-12"},
+	}
+	for _, tt := range tests {
+		if got := monthsBefore(date(tt.t), tt.n).Format("2006-01-02"); got != tt.want {
+			t.Errorf("monthsBefore(%s, %d) = %s, want %s", tt.t, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestGetPeriodReportsComparesMonthEnds(t *testing.T) {
+	s := NewMemoryStore()
+	if _, err := s.AddSubscription("steady", 10000, "USD", "month", "stripe", "", date("2024-01-15")); err != nil {
+		t.Fatal(err)
+	}
+	// Starts the day after February ends, so comparisons that spill from
+	// "February 31" into March would pick it up
+	if _, err := s.AddSubscription("march", 5000, "USD", "month", "stripe", "", date("2025-03-01")); err != nil {
+		t.Fatal(err)
+	}
+
+	months, err := GetPeriodReports(s, date("2025-01-01"), date("2025-12-31"), PeriodMonth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range months {
+		wantQuarterAgo := int64(15000)
+		if r.Period <= "2025-05" {
+			wantQuarterAgo = 10000
+		}
+		if r.QuarterAgoMRR != wantQuarterAgo {
+			t.Errorf("%s: MRR three months before = %d, want %d", r.Period, r.QuarterAgoMRR, wantQuarterAgo)
+		}
+
+		wantYearAgo := int64(10000)
+		if r.YearAgoMRR != wantYearAgo {
+			t.Errorf("%s: MRR a year before = %d, want %d", r.Period, r.YearAgoMRR, wantYearAgo)
+		}
+	}
+
+	quarters, err := GetPeriodReports(s, date("2025-01-01"), date("2025-12-31"), PeriodQuarter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"2025-Q1": 10000, "2025-Q2": 15000, "2025-Q3": 15000, "2025-Q4": 15000}
+	for _, r := range quarters {
+		if r.QuarterAgoMRR != want[r.Period] {
+			t.Errorf("%s: MRR a quarter before = %d, want %d", r.Period, r.QuarterAgoMRR, want[r.Period])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"time"
+)
+
+// ProfitAndLoss is a month's revenue against its expenses. Amounts are in
+// the reporting currency.
+type ProfitAndLoss struct {
+	Month            string
+	Currency         string
+	Revenue          int64 // MRR plus one-time revenue, as in the monthly report
+	RecurringRevenue int64
+	OneTimeRevenue   int64
+	Costs            int64 // Expenses charged in the month
+	RecurringCosts   int64 // The part of Costs from recurring expenses
+	ByCategory       map[string]int64
+	ExpenseCount     int // Expenses charged in the month
+}
+
+// NetProfit returns revenue minus costs, negative for a loss
+func (p *ProfitAndLoss) NetProfit() int64 {
+	return p.Revenue - p.Costs
+}
+
+// Margin returns net profit as a share of revenue; 0 without revenue
+func (p *ProfitAndLoss) Margin() float64 {
+	if p.Revenue == 0 {
+		return 0
+	}
+	return float64(p.NetProfit()) / float64(p.Revenue)
+}
+
+// NetBurn returns how much more was spent than earned, 0 when profitable
+func (p *ProfitAndLoss) NetBurn() int64 {
+	if p.Costs <= p.Revenue {
+		return 0
+	}
+	return p.Costs - p.Revenue
+}
+
+// GetProfitAndLoss computes the profit and loss of a month (YYYY-MM).
+// Revenue comes from GetMonthlyReport; expenses count in each month they
+// are charged in, converted at that month's rates like revenue.
+func GetProfitAndLoss(s Store, month string) (*ProfitAndLoss, error) {
+	report, err := GetMonthlyReport(s, month)
+	if err != nil {
+		return nil, err
+	}
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ProfitAndLoss{
+		Month:            month,
+		Currency:         report.Currency,
+		Revenue:          report.TotalRevenue,
+		RecurringRevenue: report.RecurringRevenue,
+		OneTimeRevenue:   report.OneTimeRevenue,
+		ByCategory:       make(map[string]int64),
+	}
+
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.ListExpenses("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expenses {
+		if !expenses[i].IsChargedIn(monthStart) {
+			continue
+		}
+		amount, err := fx.Convert(expenses[i].Amount, expenses[i].Currency, p.Currency, month)
+		if err != nil {
+			return nil, err
+		}
+		p.Costs += amount
+		p.ByCategory[expenses[i].Category] += amount
+		if expenses[i].IsRecurring() {
+			p.RecurringCosts += amount
+		}
+		p.ExpenseCount++
+	}
+
+	return p, nil
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonthlyReport contains aggregated data for a month. Amounts are in
+// Currency, the reporting currency at the time the report was generated.
+type MonthlyReport struct {
+	Month             string
+	Currency          string
+	TotalRevenue      int64
+	RecurringRevenue  int64 // MRR: subscription MRR plus manual adjustments
+	SubscriptionMRR   int64
+	AdjustmentMRR     int64 // Sum of recurring entries dated in the month
+	OneTimeRevenue    int64
+	BySource          map[string]int64
+	MRRBySource       map[string]int64 // Recurring revenue only
+	EntryCount        int
+	SubscriptionCount int
+}
+
+// GetMonthlyReport generates a report for a specific month.
+// MRR is derived from subscriptions active at the end of the month, with
+// recurring entries dated in the month added on top as manual adjustments.
+func GetMonthlyReport(s Store, month string) (*MonthlyReport, error) {
+	report := &MonthlyReport{
+		Month:       month,
+		Currency:    s.ReportingCurrency(),
+		BySource:    make(map[string]int64),
+		MRRBySource: make(map[string]int64),
+	}
+
+	monthEnd, err := monthEndDate(month)
+	if err != nil {
+		return nil, err
+	}
+
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entries for the month
+	entries, err := s.ListEntries(month, "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	report.EntryCount = len(entries)
+
+	for _, e := range entries {
+		amount, err := fx.Convert(e.Amount, e.Currency, report.Currency, month)
+		if err != nil {
+			return nil, err
+		}
+		report.TotalRevenue += amount
+		if e.Type == "recurring" {
+			report.AdjustmentMRR += amount
+			report.MRRBySource[e.Source] += amount
+		} else {
+			report.OneTimeRevenue += amount
+		}
+		report.BySource[e.Source] += amount
+	}
+
+	subs, err := s.ListActiveSubscriptions(monthEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	report.SubscriptionCount = len(subs)
+
+	for _, sub := range subs {
+		mrr, err := fx.Convert(sub.MonthlyAmount(), sub.Currency, report.Currency, month)
+		if err != nil {
+			return nil, err
+		}
+		report.SubscriptionMRR += mrr
+		report.TotalRevenue += mrr
+		report.BySource[sub.Source] += mrr
+		report.MRRBySource[sub.Source] += mrr
+	}
+
+	report.RecurringRevenue = report.SubscriptionMRR + report.AdjustmentMRR
+
+	return report, nil
+}
+
+// GetMonthMRR returns the MRR for a month (subscriptions plus adjustments)
+// in the reporting currency
+func GetMonthMRR(s Store, month string) (int64, error) {
+	report, err := GetMonthlyReport(s, month)
+	if err != nil {
+		return 0, err
+	}
+	return report.RecurringRevenue, nil
+}
+
+// GetPreviousMonthMRR gets the MRR for the previous month
+func GetPreviousMonthMRR(s Store, currentMonth string) (int64, error) {
+	t, err := time.Parse("2006-01", currentMonth)
+	if err != nil {
+		return 0, fmt.Errorf("invalid month format: %w", err)
+	}
+
+	return GetMonthMRR(s, t.AddDate(0, -1, 0).Format("2006-01"))
+}
+
+// monthEndDate returns the last day of a YYYY-MM month
+func monthEndDate(month string) (time.Time, error) {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month format: %w", err)
+	}
+	return t.AddDate(0, 1, -1), nil
+}
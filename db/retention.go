@@ -0,0 +1,124 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retention measures how the subscription MRR of the customers paying at
+// the start of a period held up by its end. Customers who joined during the
+// period and manual recurring entries don't count. Amounts are in the
+// reporting currency at the last month's exchange rates; ChurnedMRR and
+// ContractionMRR are positive.
+type Retention struct {
+	From             string // First month of the period
+	To               string // Last month of the period
+	Months           int
+	StartCustomers   int   // Customers paying at the end of the month before From
+	ChurnedCustomers int   // Of those, customers no longer paying at the end of To
+	StartMRR         int64 // MRR of the starting customers at the start
+	ChurnedMRR       int64 // Starting MRR of the customers who churned
+	ContractionMRR   int64 // MRR lost by customers who downgraded
+	ExpansionMRR     int64 // MRR gained by customers who upgraded
+	EndMRR           int64 // MRR of the starting customers at the end of To
+}
+
+// LogoChurnRate returns the share of starting customers lost, 0 to 1
+func (r *Retention) LogoChurnRate() float64 {
+	if r.StartCustomers == 0 {
+		return 0
+	}
+	return float64(r.ChurnedCustomers) / float64(r.StartCustomers)
+}
+
+// GrossMRRChurnRate returns the share of starting MRR lost to churn and
+// contraction, 0 to 1
+func (r *Retention) GrossMRRChurnRate() float64 {
+	if r.StartMRR == 0 {
+		return 0
+	}
+	return float64(r.ChurnedMRR+r.ContractionMRR) / float64(r.StartMRR)
+}
+
+// GrossRevenueRetention returns the share of starting MRR kept, ignoring
+// expansion, 0 to 1
+func (r *Retention) GrossRevenueRetention() float64 {
+	if r.StartMRR == 0 {
+		return 0
+	}
+	return 1 - r.GrossMRRChurnRate()
+}
+
+// NetRevenueRetention returns the starting customers' MRR at the end as a
+// share of their MRR at the start, including expansion; above 1 when
+// upgrades outweigh churn
+func (r *Retention) NetRevenueRetention() float64 {
+	if r.StartMRR == 0 {
+		return 0
+	}
+	return float64(r.EndMRR) / float64(r.StartMRR)
+}
+
+// GetRetention computes retention over the months from..to (YYYY-MM,
+// inclusive) from subscription history
+func GetRetention(s Store, from, to string) (*Retention, error) {
+	start, err := time.Parse("2006-01", from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month format: %w", err)
+	}
+	end, err := monthEndDate(to)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end month %s is before start month %s", to, from)
+	}
+	prevEnd := start.AddDate(0, 0, -1)
+
+	subs, err := s.ListSubscriptions(false)
+	if err != nil {
+		return nil, err
+	}
+	fx, err := LoadFXTable(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both ends at the last month's rates so exchange rate moves don't count
+	// as expansion or contraction
+	startByCustomer, err := customerMRRAtRates(subs, fx, s.ReportingCurrency(), prevEnd, to)
+	if err != nil {
+		return nil, err
+	}
+	endByCustomer, err := customerMRRAt(subs, fx, s.ReportingCurrency(), end)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Retention{
+		From:   from,
+		To:     to,
+		Months: (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1,
+	}
+	for customer, before := range startByCustomer {
+		if before <= 0 {
+			continue
+		}
+		after := endByCustomer[customer]
+
+		r.StartCustomers++
+		r.StartMRR += before
+		r.EndMRR += after
+		switch {
+		case after == 0:
+			r.ChurnedCustomers++
+			r.ChurnedMRR += before
+		case after < before:
+			r.ContractionMRR += before - after
+		case after > before:
+			r.ExpansionMRR += after - before
+		}
+	}
+
+	return r, nil
+}
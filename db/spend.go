@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// SetAcquisitionSpend records the acquisition spend for a month, replacing
+// any existing spend for that month
+func (s *SQLiteStore) SetAcquisitionSpend(month string, amount int64, currency, note string) error {
+	_, err := s.db.Exec(
+		"INSERT OR REPLACE INTO acquisition_spend (month, amount, currency, note) VALUES (?, ?, ?, ?)",
+		month, amount, currency, note,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set acquisition spend: %w", err)
+	}
+	return nil
+}
+
+// ListAcquisitionSpend lists the acquisition spend of every month ordered by month
+func (s *SQLiteStore) ListAcquisitionSpend() ([]models.AcquisitionSpend, error) {
+	rows, err := s.db.Query("SELECT month, amount, currency, note FROM acquisition_spend ORDER BY month")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list acquisition spend: %w", err)
+	}
+	defer rows.Close()
+
+	var spend []models.AcquisitionSpend
+	for rows.Next() {
+		var sp models.AcquisitionSpend
+		var note sql.NullString
+		if err := rows.Scan(&sp.Month, &sp.Amount, &sp.Currency, &note); err != nil {
+			return nil, fmt.Errorf("failed to scan acquisition spend: %w", err)
+		}
+		sp.Note = note.String
+		spend = append(spend, sp)
+	}
+
+	return spend, rows.Err()
+}
+
+// DeleteAcquisitionSpend deletes the acquisition spend of a month
+func (s *SQLiteStore) DeleteAcquisitionSpend(month string) error {
+	result, err := s.db.Exec("DELETE FROM acquisition_spend WHERE month = ?", month)
+	if err != nil {
+		return fmt.Errorf("failed to delete acquisition spend: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no acquisition spend for %s", month)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Store is the storage backend behind the CLI, TUI and dashboard.
+// SQLiteStore persists to a database file; MemoryStore keeps everything in
+// memory for embedding mrr-cli as a library and for hermetic tests.
+//
+// Reports, movements and customer stats are computed from a Store by the
+// functions in this package, so every implementation reports identically.
+type Store interface {
+	// AddEntry adds a new revenue entry. customerID may be 0 for no customer.
+	AddEntry(amount int64, currency, source, entryType, note string, date time.Time, customerID int64) (int64, error)
+	// GetEntry retrieves a single entry by ID
+	GetEntry(id int64) (*models.Entry, error)
+	// ListEntries lists entries newest first with optional filters.
+	// customerID 0 means any customer.
+	ListEntries(month, source, entryType string, customerID int64) ([]models.Entry, error)
+	// UpdateEntry updates the non-nil fields of an entry. A customerID of 0
+	// unlinks the customer.
+	UpdateEntry(id int64, amount *int64, currency, source, note *string, customerID *int64) error
+	// DeleteEntry deletes an entry by ID
+	DeleteEntry(id int64) error
+
+	// AddSubscription adds a new subscription linked to the named customer,
+	// adding the customer if it doesn't exist
+	AddSubscription(customer string, amount int64, currency, interval, source, note string, startDate time.Time) (int64, error)
+	// GetSubscription retrieves a single subscription by ID
+	GetSubscription(id int64) (*models.Subscription, error)
+	// ListSubscriptions lists subscriptions newest first, optionally only
+	// those not cancelled
+	ListSubscriptions(activeOnly bool) ([]models.Subscription, error)
+	// ListActiveSubscriptions lists subscriptions active on the given day
+	ListActiveSubscriptions(at time.Time) ([]models.Subscription, error)
+	// CancelSubscription marks a subscription as cancelled from the given date
+	CancelSubscription(id int64, cancelDate time.Time) error
+
+	// AddExpense adds a new expense. endDate is nil for one-time expenses
+	// and recurring ones that continue indefinitely.
+	AddExpense(amount int64, currency, category, interval, note string, date time.Time, endDate *time.Time) (int64, error)
+	// GetExpense retrieves a single expense by ID
+	GetExpense(id int64) (*models.Expense, error)
+	// ListExpenses lists expenses newest first, optionally of one category
+	ListExpenses(category string) ([]models.Expense, error)
+	// UpdateExpense updates the non-nil fields of an expense. A zero
+	// endDate makes a recurring expense continue indefinitely.
+	UpdateExpense(id int64, amount *int64, currency, category, interval, note *string, date, endDate *time.Time) error
+	// DeleteExpense deletes an expense by ID
+	DeleteExpense(id int64) error
+
+	// AddCustomer adds a new customer; names are unique
+	AddCustomer(name, email, note string) (int64, error)
+	// GetCustomer retrieves a single customer by ID
+	GetCustomer(id int64) (*models.Customer, error)
+	// GetCustomerByName retrieves a single customer by name
+	GetCustomerByName(name string) (*models.Customer, error)
+	// ListCustomers lists all customers ordered by name
+	ListCustomers() ([]models.Customer, error)
+
+	// SetFXRate records the BaseCurrency value of one unit of currency for
+	// a month, replacing any existing rate for that month
+	SetFXRate(currency, month string, rate float64) error
+	// ListFXRates lists all FX rates ordered by currency and month
+	ListFXRates() ([]models.FXRate, error)
+
+	// SetAcquisitionSpend records what was spent acquiring customers in a
+	// month, replacing any existing spend for that month
+	SetAcquisitionSpend(month string, amount int64, currency, note string) error
+	// ListAcquisitionSpend lists the acquisition spend of every month
+	// ordered by month
+	ListAcquisitionSpend() ([]models.AcquisitionSpend, error)
+	// DeleteAcquisitionSpend deletes the acquisition spend of a month
+	DeleteAcquisitionSpend(month string) error
+
+	// ReportingCurrency returns the currency reports are converted into
+	ReportingCurrency() string
+	// SetReportingCurrency sets the currency reports are converted into
+	SetReportingCurrency(currency string)
+
+	// ImportEntry inserts an imported entry, or updates the entry previously
+	// imported with the same ImportKey if its imported fields changed. It
+	// sets e.ID and reports which happened. New entries keep e.Origin,
+	// defaulting to import; updates leave the origin alone.
+	ImportEntry(e *models.Entry) (ImportOutcome, error)
+	// ImportSubscription is ImportEntry for subscriptions, which are linked
+	// to customers like AddSubscription links them
+	ImportSubscription(sub *models.Subscription) (ImportOutcome, error)
+	// DeleteImportedEntries deletes the entry imported under key and the
+	// entries a multi-month payment imported under it was spread into
+	// (key#1, key#2, ...), returning how many were deleted
+	DeleteImportedEntries(key string) (int, error)
+
+	// GetSyncCursor returns where the last sync from a provider stopped, 0
+	// if it never ran
+	GetSyncCursor(provider string) (int64, error)
+	// SetSyncCursor records where a sync from a provider stopped
+	SetSyncCursor(provider string, cursor int64) error
+
+	// AddAPIToken stores a new REST API token by the hash of its secret;
+	// names are unique
+	AddAPIToken(name, scope, hash, prefix string) (int64, error)
+	// GetAPITokenByHash retrieves the token whose secret hashes to hash
+	GetAPITokenByHash(hash string) (*models.APIToken, error)
+	// ListAPITokens lists all API tokens in the order they were created
+	ListAPITokens() ([]models.APIToken, error)
+	// TouchAPIToken records when a token was last used
+	TouchAPIToken(id int64, at time.Time) error
+	// DeleteAPIToken revokes a token
+	DeleteAPIToken(id int64) error
+
+	// Transaction runs fn against a view of the store whose writes all take
+	// effect if fn returns nil and are discarded if it returns an error
+	Transaction(fn func(tx Store) error) error
+
+	// Close releases the store's resources
+	Close() error
+}
+
+// ImportOutcome says what importing a row did
+type ImportOutcome int
+
+const (
+	ImportInserted  ImportOutcome = iota // New row
+	ImportUpdated                        // Existing row with changed fields
+	ImportDuplicate                      // Existing row, unchanged
+)
+
+var (
+	_ Store = (*SQLiteStore)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
+
+// currencySetting holds a store's reporting currency
+type currencySetting struct {
+	currency string
+}
+
+func (c *currencySetting) ReportingCurrency() string {
+	if c.currency == "" {
+		return models.BaseCurrency
+	}
+	return c.currency
+}
+
+func (c *currencySetting) SetReportingCurrency(currency string) {
+	c.currency = currency
+}
+
+// sameImportedEntry reports whether importing e over existing would change
+// nothing. An import without a customer leaves the existing link alone.
+func sameImportedEntry(existing, e *models.Entry) bool {
+	return existing.Amount == e.Amount &&
+		existing.Currency == e.Currency &&
+		existing.Source == e.Source &&
+		existing.Type == e.Type &&
+		existing.Note == e.Note &&
+		existing.Date.Equal(day(e.Date)) &&
+		(e.CustomerID == 0 || existing.CustomerID == e.CustomerID)
+}
+
+// sameImportedSubscription reports whether importing sub over existing
+// would change nothing
+func sameImportedSubscription(existing, sub *models.Subscription) bool {
+	sameCancel := existing.CancelDate == nil && sub.CancelDate == nil ||
+		existing.CancelDate != nil && sub.CancelDate != nil && existing.CancelDate.Equal(day(*sub.CancelDate))
+	return existing.Customer == sub.Customer &&
+		existing.Amount == sub.Amount &&
+		existing.Currency == sub.Currency &&
+		existing.Interval == sub.Interval &&
+		existing.Source == sub.Source &&
+		existing.Note == sub.Note &&
+		existing.StartDate.Equal(day(sub.StartDate)) &&
+		sameCancel
+}
+
+// importOrigin is the origin recorded for a new imported row
+func importOrigin(origin string) string {
+	if origin == "" {
+		return models.OriginImport
+	}
+	return origin
+}
+
+// checkCancel validates cancelling a subscription on cancelDate
+func checkCancel(sub *models.Subscription, cancelDate time.Time) error {
+	if sub.CancelDate != nil {
+		return fmt.Errorf("subscription %d already cancelled on %s", sub.ID, sub.CancelDate.Format("2006-01-02"))
+	}
+	if cancelDate.Before(sub.StartDate) {
+		return fmt.Errorf("cancel date %s is before start date %s", cancelDate.Format("2006-01-02"), sub.StartDate.Format("2006-01-02"))
+	}
+	return nil
+}
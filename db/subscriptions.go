@@ -0,0 +1,151 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+const subscriptionColumns = "id, customer, customer_id, amount, interval, source, currency, start_date, cancel_date, note, created_at, import_key, origin"
+
+// AddSubscription adds a new subscription
+func (s *SQLiteStore) AddSubscription(customer string, amount int64, currency, interval, source, note string, startDate time.Time) (int64, error) {
+	customerID, err := s.subscriptionCustomer(customer)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.Exec(
+		"INSERT INTO subscriptions (customer, customer_id, amount, currency, interval, source, note, start_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		customer, nullableID(customerID), amount, currency, interval, source, note, startDate.Format("2006-01-02"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add subscription: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetSubscription retrieves a single subscription by ID
+func (s *SQLiteStore) GetSubscription(id int64) (*models.Subscription, error) {
+	row := s.db.QueryRow("SELECT "+subscriptionColumns+" FROM subscriptions WHERE id = ?", id)
+
+	sub, err := scanSubscription(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("subscription not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// ListSubscriptions lists subscriptions, optionally only those still active
+func (s *SQLiteStore) ListSubscriptions(activeOnly bool) ([]models.Subscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM subscriptions"
+	if activeOnly {
+		query += " WHERE cancel_date IS NULL"
+	}
+	query += " ORDER BY start_date DESC, id DESC"
+
+	return s.querySubscriptions(query)
+}
+
+// ListActiveSubscriptions lists subscriptions active on the given day
+func (s *SQLiteStore) ListActiveSubscriptions(at time.Time) ([]models.Subscription, error) {
+	day := at.Format("2006-01-02")
+	return s.querySubscriptions(
+		"SELECT "+subscriptionColumns+" FROM subscriptions WHERE start_date <= ? AND (cancel_date IS NULL OR cancel_date > ?) ORDER BY start_date, id",
+		day, day,
+	)
+}
+
+// CancelSubscription marks a subscription as cancelled from the given date
+func (s *SQLiteStore) CancelSubscription(id int64, cancelDate time.Time) error {
+	sub, err := s.GetSubscription(id)
+	if err != nil {
+		return err
+	}
+	if err := checkCancel(sub, cancelDate); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(
+		"UPDATE subscriptions SET cancel_date = ? WHERE id = ?",
+		cancelDate.Format("2006-01-02"), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	return nil
+}
+
+// subscriptionCustomer returns the ID of the customer a subscription names,
+// adding the customer if it doesn't exist, or 0 for an empty name
+func (s *SQLiteStore) subscriptionCustomer(name string) (int64, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	customer, err := s.GetCustomerByName(name)
+	if err == nil {
+		return customer.ID, nil
+	}
+	result, err := s.db.Exec("INSERT INTO customers (name) VALUES (?)", name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add customer: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+func (s *SQLiteStore) querySubscriptions(query string, args ...interface{}) ([]models.Subscription, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []models.Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func scanSubscription(row rowScanner) (*models.Subscription, error) {
+	var sub models.Subscription
+	var startStr string
+	var createdAtStr string
+	var cancelStr sql.NullString
+	var note sql.NullString
+	var importKey sql.NullString
+	var customerID sql.NullInt64
+
+	err := row.Scan(&sub.ID, &sub.Customer, &customerID, &sub.Amount, &sub.Interval, &sub.Source, &sub.Currency,
+		&startStr, &cancelStr, &note, &createdAtStr, &importKey, &sub.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.CustomerID = customerID.Int64
+	sub.StartDate = parseDate(startStr)
+	sub.CreatedAt = parseDateTime(createdAtStr)
+	if cancelStr.Valid {
+		cancelDate := parseDate(cancelStr.String)
+		sub.CancelDate = &cancelDate
+	}
+	if note.Valid {
+		sub.Note = note.String
+	}
+	sub.ImportKey = importKey.String
+
+	return &sub, nil
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetSyncCursor returns where the last sync from a provider stopped, 0 if it
+// never ran
+func (s *SQLiteStore) GetSyncCursor(provider string) (int64, error) {
+	var cursor int64
+	err := s.db.QueryRow("SELECT cursor FROM sync_cursors WHERE provider = ?", provider).Scan(&cursor)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sync cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// SetSyncCursor records where a sync from a provider stopped
+func (s *SQLiteStore) SetSyncCursor(provider string, cursor int64) error {
+	_, err := s.db.Exec(
+		"INSERT INTO sync_cursors (provider, cursor, synced_at) VALUES (?, ?, CURRENT_TIMESTAMP) ON CONFLICT(provider) DO UPDATE SET cursor = excluded.cursor, synced_at = excluded.synced_at",
+		provider, cursor,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save sync cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+const tokenColumns = "id, name, scope, token_hash, prefix, created_at, last_used_at"
+
+// AddAPIToken stores a new API token by its hash; names are unique
+func (s *SQLiteStore) AddAPIToken(name, scope, hash, prefix string) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO api_tokens (name, scope, token_hash, prefix) VALUES (?, ?, ?, ?)",
+		name, scope, hash, prefix,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return 0, fmt.Errorf("token already exists: %s", name)
+		}
+		return 0, fmt.Errorf("failed to add token: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetAPITokenByHash retrieves the token with the given hash
+func (s *SQLiteStore) GetAPITokenByHash(hash string) (*models.APIToken, error) {
+	row := s.db.QueryRow("SELECT "+tokenColumns+" FROM api_tokens WHERE token_hash = ?", hash)
+
+	token, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("token not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListAPITokens lists all API tokens in the order they were created
+func (s *SQLiteStore) ListAPITokens() ([]models.APIToken, error) {
+	rows, err := s.db.Query("SELECT " + tokenColumns + " FROM api_tokens ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []models.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan token: %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// TouchAPIToken records when a token was last used
+func (s *SQLiteStore) TouchAPIToken(id int64, at time.Time) error {
+	_, err := s.db.Exec("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", at.UTC().Format("2006-01-02 15:04:05"), id)
+	if err != nil {
+		return fmt.Errorf("failed to update token: %w", err)
+	}
+	return nil
+}
+
+// DeleteAPIToken revokes a token
+func (s *SQLiteStore) DeleteAPIToken(id int64) error {
+	result, err := s.db.Exec("DELETE FROM api_tokens WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("token not found: %d", id)
+	}
+
+	return nil
+}
+
+func scanAPIToken(row rowScanner) (*models.APIToken, error) {
+	var token models.APIToken
+	var createdAtStr string
+	var lastUsedStr sql.NullString
+
+	if err := row.Scan(&token.ID, &token.Name, &token.Scope, &token.Hash, &token.Prefix, &createdAtStr, &lastUsedStr); err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt = parseDateTime(createdAtStr)
+	if lastUsedStr.Valid {
+		lastUsed := parseDateTime(lastUsedStr.String)
+		token.LastUsedAt = &lastUsed
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,99 @@
+package importer
+
+// parseGumroad parses Gumroad's sales export. Refunded, chargebacked and
+// disputed sales are skipped and partial refunds netted out. Sales with a
+// recurrence are recurring; those covering several months (yearly
+// memberships) are spread evenly over the months they pay for. Amounts are
+// in USD unless the export has a currency column.
+func (p *parser) parseGumroad(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"Sale Price ($)", "Price ($)", "Sale Price", "Price", "Subtotal ($)"}
+	dateCols := []string{"Purchase Date", "Sale Date", "Date"}
+	if err := cols.require(amountCols, dateCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		if isTrue(cols.get(record, "Fully Refunded?", "Refunded?", "Refunded")) {
+			result.skip(line, "refunded sale")
+			continue
+		}
+		if isTrue(cols.get(record, "Chargebacked?", "Chargebacked")) {
+			result.skip(line, "chargebacked sale")
+			continue
+		}
+		if isTrue(cols.get(record, "Disputed?", "Disputed")) && !isTrue(cols.get(record, "Dispute Won?", "Dispute Won")) {
+			result.skip(line, "disputed sale")
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if refund := cols.get(record, "Partial Refund ($)", "Partial Refund"); refund != "" {
+			refundAmount, err := p.amount(refund)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+			amount -= refundAmount
+		}
+		if amount <= 0 {
+			result.skip(line, "free or refunded sale")
+			continue
+		}
+
+		date, err := p.date(cols.get(record, dateCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency := "USD"
+		if c := cols.get(record, "Currency"); c != "" {
+			currency, err = currencyCode(c)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+		}
+
+		entry := Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Currency: currency,
+			Source:   "gumroad",
+			Type:     "one-time",
+			Note:     cols.get(record, "Item Name", "Product Name", "Product", "Purchase ID"),
+			Customer: cols.get(record, "Buyer Name", "Full Name", "Purchase Email", "Buyer Email", "Email"),
+
+			ExternalID: cols.get(record, "Purchase ID", "Sale ID"),
+		}
+
+		recurrence := cols.get(record, "Recurrence", "Subscription Recurrence")
+		months, known := recurrenceMonths(recurrence)
+		recurring := known ||
+			isTrue(cols.get(record, "Is Recurring Charge?", "Is Recurring Charge")) ||
+			cols.get(record, "Subscription ID") != ""
+		if !recurring {
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+
+		entry.Type = "recurring"
+		if months <= 1 {
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		result.Entries = append(result.Entries, spreadMonthly(entry, date, months)...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,508 @@
+// Package importer parses revenue exports (the tool's own CSV and payment
+// providers' native exports) into entries and subscriptions ready to store.
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// Entry is a revenue entry parsed from an import row
+type Entry struct {
+	Line     int
+	Date     time.Time
+	Amount   int64  // Amount in cents
+	Currency string // Empty means the caller's default currency
+	Source   string
+	Type     string
+	Note     string
+	Customer string // Customer name or ID, empty for none
+
+	ExternalID string // The provider's ID for the row, if any
+	Key        string // Import key stored with the entry to detect re-imports
+}
+
+// Subscription is a subscription parsed from an import row
+type Subscription struct {
+	Line       int
+	Customer   string
+	Amount     int64 // Plan amount in cents per billing interval
+	Currency   string
+	Interval   string
+	Source     string
+	Note       string
+	StartDate  time.Time
+	CancelDate *time.Time
+
+	ExternalID string
+	Key        string
+}
+
+// Skip records a row that was not imported and why
+type Skip struct {
+	Line   int
+	Reason string
+}
+
+// Result holds everything parsed from an import
+type Result struct {
+	Entries       []Entry
+	Subscriptions []Subscription
+	Skipped       []Skip // Valid rows deliberately left out (refunds, trials, ...)
+	Rejected      []Skip // Invalid rows
+
+	// Superseded holds the import keys of skipped rows that earlier versions
+	// stored as entries, such as subscription payments now carried by the
+	// subscription's MRR. Entries stored under them should be deleted.
+	Superseded []string
+}
+
+func (r *Result) skip(line int, format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, Skip{Line: line, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (r *Result) reject(line int, format string, args ...interface{}) {
+	r.Rejected = append(r.Rejected, Skip{Line: line, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (r *Result) supersede(source, externalID string) {
+	r.Superseded = append(r.Superseded, externalKey(source, externalID))
+}
+
+// externalKey is the import key of a row with a provider ID
+func externalKey(source, externalID string) string {
+	return source + ":" + externalID
+}
+
+// Formats lists the supported import formats
+var Formats = []string{
+	"mrr",
+	"stripe-payments", "stripe-invoices", "stripe-subscriptions",
+	"gumroad",
+	"paddle-transactions", "paddle-subscriptions",
+	"lemonsqueezy",
+	"json", "jsonl",
+}
+
+// csvParsers maps each CSV format to its row parser
+var csvParsers = map[string]func(p *parser, header []string, rows [][]string) (*Result, error){
+	"mrr":                  (*parser).parseMRR,
+	"stripe-payments":      (*parser).parseStripePayments,
+	"stripe-invoices":      (*parser).parseStripeInvoices,
+	"stripe-subscriptions": (*parser).parseStripeSubscriptions,
+	"gumroad":              (*parser).parseGumroad,
+	"paddle-transactions":  (*parser).parsePaddleTransactions,
+	"paddle-subscriptions": (*parser).parsePaddleSubscriptions,
+	"lemonsqueezy":         (*parser).parseLemonSqueezy,
+}
+
+// Options adjust how a file is read. The zero value reads exports as the
+// providers write them.
+type Options struct {
+	// Mapping maps the column names a format expects to the file's own
+	// header names, such as "date" to "Paid On"
+	Mapping map[string]string
+	// DateLayouts are tried before the built-in date formats. Go layouts
+	// ("02/01/2006") and YYYY/MM/DD patterns ("DD.MM.YYYY") are accepted.
+	DateLayouts []string
+	// Decimal is the decimal separator, "." by default
+	Decimal string
+	// Thousands is the thousands separator. By default any of ",", ".",
+	// spaces and apostrophes other than the decimal separator is ignored.
+	Thousands string
+	// Source and Type are used for rows of the mrr format without a source
+	// or type column
+	Source string
+	Type   string
+}
+
+// parser reads rows with a set of options
+type parser struct {
+	Options
+	dateLayouts []string
+}
+
+// Parse reads an export in the given format
+func Parse(format string, r io.Reader, opts Options) (*Result, error) {
+	p := &parser{Options: opts}
+
+	var read func(r io.Reader) (*Result, error)
+	switch format {
+	case "json":
+		read = p.parseJSON
+	case "jsonl":
+		read = p.parseJSONLines
+	default:
+		parse, ok := csvParsers[format]
+		if !ok {
+			return nil, fmt.Errorf("unknown import format: %s (valid: %v)", format, Formats)
+		}
+		read = func(r io.Reader) (*Result, error) {
+			return p.parseCSV(parse, r)
+		}
+	}
+
+	if p.Decimal == "" {
+		p.Decimal = "."
+	}
+	if utf8.RuneCountInString(p.Decimal) != 1 || utf8.RuneCountInString(p.Thousands) > 1 {
+		return nil, fmt.Errorf("decimal and thousands separators must be single characters")
+	}
+	if p.Decimal == p.Thousands {
+		return nil, fmt.Errorf("decimal and thousands separators must differ")
+	}
+	for _, layout := range opts.DateLayouts {
+		p.dateLayouts = append(p.dateLayouts, goLayout(layout))
+	}
+	p.dateLayouts = append(p.dateLayouts, dateLayouts...)
+
+	result, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "json" || format == "jsonl" {
+		reverseEntries(result.Entries)
+	}
+
+	assignKeys(result)
+
+	return result, nil
+}
+
+// parseCSV reads a CSV file and hands its mapped header and rows to parse
+func (p *parser) parseCSV(parse func(p *parser, header []string, rows [][]string) (*Result, error), r io.Reader) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("CSV file is empty or has only headers")
+	}
+
+	header, err := applyMapping(records[0], p.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(p, header, records[1:])
+}
+
+// assignKeys gives every parsed row an import key: the source and provider
+// ID when there is one, otherwise a hash of the row's content. Identical
+// rows within one file are numbered so they stay distinct.
+func assignKeys(result *Result) {
+	seen := make(map[string]int)
+	key := func(source, externalID string, fields ...interface{}) string {
+		if externalID != "" {
+			return externalKey(source, externalID)
+		}
+		h := sha256.New()
+		fmt.Fprintln(h, source)
+		for _, f := range fields {
+			fmt.Fprintln(h, f)
+		}
+		k := "hash:" + hex.EncodeToString(h.Sum(nil))[:32]
+		seen[k]++
+		if n := seen[k]; n > 1 {
+			k = fmt.Sprintf("%s#%d", k, n)
+		}
+		return k
+	}
+
+	for i := range result.Entries {
+		e := &result.Entries[i]
+		e.Key = key(e.Source, e.ExternalID,
+			e.Date.Format("2006-01-02"), e.Amount, e.Currency, e.Type, e.Note, e.Customer)
+	}
+	for i := range result.Subscriptions {
+		s := &result.Subscriptions[i]
+		s.Key = key(s.Source, s.ExternalID,
+			s.Customer, s.StartDate.Format("2006-01-02"), s.Amount, s.Currency, s.Interval)
+	}
+}
+
+// applyMapping renames the mapped columns of a header to the names the format
+// expects. Any other column already using an expected name is hidden so the
+// mapped one wins.
+func applyMapping(header []string, mapping map[string]string) ([]string, error) {
+	if len(mapping) == 0 {
+		return header, nil
+	}
+
+	renamed := make([]string, len(header))
+	copy(renamed, header)
+
+	indexes := make(map[string]int)
+	for name, column := range mapping {
+		i := indexOf(header, column)
+		if i < 0 {
+			return nil, fmt.Errorf("mapped column %q not found in CSV header", column)
+		}
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for i, column := range header {
+		if _, taken := indexes[normalizeHeader(column)]; taken {
+			renamed[i] = ""
+		}
+	}
+	for name, i := range indexes {
+		renamed[i] = name
+	}
+
+	return renamed, nil
+}
+
+// indexOf returns the position of a column in a header, or -1
+func indexOf(header []string, column string) int {
+	column = normalizeHeader(column)
+	for i, name := range header {
+		if normalizeHeader(name) == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeHeader lowercases a header name and strips whitespace and any
+// byte order mark
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
+
+// lineNumber maps a data row index to its line in the file, accounting for
+// the header and 0-indexing
+func lineNumber(i int) int {
+	return i + 2
+}
+
+// columns looks up CSV fields by header name, ignoring case and surrounding
+// whitespace
+type columns map[string]int
+
+func newColumns(header []string) columns {
+	c := make(columns)
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if key == "" {
+			continue
+		}
+		if _, exists := c[key]; !exists {
+			c[key] = i
+		}
+	}
+	return c
+}
+
+// has reports whether any of the names is a column
+func (c columns) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := c[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the first non-empty trimmed value among the named columns
+func (c columns) get(record []string, names ...string) string {
+	for _, name := range names {
+		if i, ok := c[strings.ToLower(name)]; ok && i < len(record) {
+			if v := strings.TrimSpace(record[i]); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// require returns an error naming the first column group missing from the header
+func (c columns) require(groups ...[]string) error {
+	for _, names := range groups {
+		if !c.has(names...) {
+			return fmt.Errorf("missing column %q in CSV header", names[0])
+		}
+	}
+	return nil
+}
+
+// amount parses a decimal amount in major units ("1,234.56", "$49",
+// "(12.50)" for -12.50) into cents using the parser's separators
+func (p *parser) amount(s string) (int64, error) {
+	original := s
+	s = strings.TrimSpace(s)
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = s[1:]
+	}
+	s = strings.TrimLeft(s, "$€£¥ ")
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = s[1:]
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case string(r) == p.Decimal:
+			b.WriteRune('.')
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case p.Thousands != "" && string(r) == p.Thousands:
+		case p.Thousands == "" && strings.ContainsRune(",. '\u00a0\u202f", r):
+		default:
+			return 0, fmt.Errorf("invalid amount '%s'", strings.TrimSpace(original))
+		}
+	}
+
+	f, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount '%s'", strings.TrimSpace(original))
+	}
+	if negative {
+		f = -f
+	}
+	return int64(math.Round(f * 100)), nil
+}
+
+// dateLayouts are the timestamp formats found in provider exports
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.RFC3339,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"1/2/2006",
+	"1/2/2006 15:04",
+	"1/2/2006 15:04:05",
+	"Jan 2, 2006",
+	"Jan 2, 2006 15:04",
+	"January 2, 2006",
+}
+
+// goLayout turns a YYYY/MM/DD style date pattern into a Go time layout.
+// Go layouts pass through unchanged.
+func goLayout(pattern string) string {
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DD", "02",
+		"hh", "15",
+		"mm", "04",
+		"ss", "05",
+	).Replace(pattern)
+}
+
+// date parses a timestamp with the parser's date layouts, keeping only the
+// day
+func (p *parser) date(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range p.dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s'", s)
+}
+
+// spreadMonthly splits a multi-month payment into one entry per month
+// starting at start, on the same day of each month or the last day of
+// shorter ones. Any remainder cents go to the first month.
+func spreadMonthly(entry Entry, start time.Time, months int) []Entry {
+	perMonth := entry.Amount / int64(months)
+	remainder := entry.Amount - perMonth*int64(months)
+
+	entries := make([]Entry, months)
+	for i := range entries {
+		e := entry
+		e.Date = models.AddMonthsClamped(start, i)
+		e.Amount = perMonth
+		if i == 0 {
+			e.Amount += remainder
+		}
+		e.Note = strings.TrimSpace(fmt.Sprintf("%s (%d/%d)", entry.Note, i+1, months))
+		if entry.ExternalID != "" {
+			e.ExternalID = fmt.Sprintf("%s#%d", entry.ExternalID, i+1)
+		}
+		entries[i] = e
+	}
+	return entries
+}
+
+// normalizeInterval maps a provider billing interval and count onto the
+// month/year intervals subscriptions support, adjusting the amount
+func normalizeInterval(interval string, count int, amount int64) (string, int64, error) {
+	switch interval {
+	case "month":
+		if count == 12 {
+			return "year", amount, nil
+		}
+		return "month", amount / int64(count), nil
+	case "year":
+		return "year", amount / int64(count), nil
+	case "week":
+		return "month", amount * 52 / 12 / int64(count), nil
+	case "day":
+		return "month", amount * 365 / 12 / int64(count), nil
+	default:
+		return "", 0, fmt.Errorf("invalid interval '%s'", interval)
+	}
+}
+
+// currencyCode normalizes a provider's currency code, which may be lowercase
+func currencyCode(s string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(s))
+	if !models.IsValidCurrency(currency) {
+		return "", fmt.Errorf("invalid currency '%s'", s)
+	}
+	return currency, nil
+}
+
+// isTrue interprets the boolean spellings found in exports
+func isTrue(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "y", "1":
+		return true
+	}
+	return false
+}
+
+// recurrenceMonths returns the number of months a recurrence name covers
+// ("monthly", "quarterly", "yearly", ...)
+func recurrenceMonths(s string) (int, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "monthly", "month":
+		return 1, true
+	case "quarterly", "quarter":
+		return 3, true
+	case "biannually", "semiannually", "every_six_months":
+		return 6, true
+	case "yearly", "annually", "annual", "year":
+		return 12, true
+	case "every_two_years":
+		return 24, true
+	}
+	return 0, false
+}
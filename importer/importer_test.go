@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpreadMonthlyClampsToMonthEnds(t *testing.T) {
+	start := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	entries := spreadMonthly(Entry{Amount: 1200, ExternalID: "in_1"}, start, 4)
+
+	want := []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30"}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		if got := e.Date.Format("2006-01-02"); got != want[i] {
+			t.Errorf("entry %d dated %s, want %s", i+1, got, want[i])
+		}
+		if e.Amount != 300 {
+			t.Errorf("entry %d amount = %d, want 300", i+1, e.Amount)
+		}
+	}
+}
+
+func TestSubscriptionPaymentsLeaveMRRToSubscriptions(t *testing.T) {
+	tests := []struct {
+		format     string
+		csv        string
+		entries    []string
+		superseded []string
+	}{
+		{
+			"stripe-invoices",
+			"id,Status,Amount Paid,Currency,Date (UTC),Subscription,Period Start (UTC),Period End (UTC)\n" +
+				"in_1,paid,120.00,usd,2026-01-31,sub_1,2026-01-31,2027-01-31\n" +
+				"in_2,paid,50.00,usd,2026-02-03,,,\n",
+			[]string{"stripe:in_2"},
+			[]string{"stripe:in_1"},
+		},
+		{
+			"stripe-payments",
+			"id,Status,Amount,Currency,Created (UTC),Invoice ID,Subscription\n" +
+				"ch_1,Paid,29.00,usd,2026-02-01,in_1,sub_1\n" +
+				"ch_2,Paid,50.00,usd,2026-02-03,in_2,\n",
+			[]string{"stripe:ch_2"},
+			[]string{"stripe:ch_1"},
+		},
+		{
+			"paddle-transactions",
+			"id,status,origin,subscription_id,details.totals.grand_total,currency_code,billed_at\n" +
+				"txn_1,completed,subscription_recurring,sub_1,29.00,USD,2026-02-01\n" +
+				"txn_2,completed,api,,50.00,USD,2026-02-03\n",
+			[]string{"paddle:txn_2"},
+			[]string{"paddle:txn_1"},
+		},
+	}
+	for _, tt := range tests {
+		result, err := Parse(tt.format, strings.NewReader(tt.csv), Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+
+		var keys []string
+		for _, e := range result.Entries {
+			if e.Type != "one-time" {
+				t.Errorf("%s: %s is %s, want one-time", tt.format, e.Key, e.Type)
+			}
+			keys = append(keys, e.Key)
+		}
+		if strings.Join(keys, ",") != strings.Join(tt.entries, ",") {
+			t.Errorf("%s: entries %v, want %v", tt.format, keys, tt.entries)
+		}
+		if strings.Join(result.Superseded, ",") != strings.Join(tt.superseded, ",") {
+			t.Errorf("%s: superseded %v, want %v", tt.format, result.Superseded, tt.superseded)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// jsonEntry is an entry as written by 'mrr export --json'
+type jsonEntry struct {
+	Date     string   `json:"date"`
+	Amount   *float64 `json:"amount"`
+	Currency string   `json:"currency"`
+	Source   string   `json:"source"`
+	Type     string   `json:"type"`
+	Note     string   `json:"note"`
+	Customer string   `json:"customer,omitempty"`
+}
+
+// parseJSON reads the array written by 'mrr export --json'
+func (p *parser) parseJSON(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	// An export of no entries is "null"
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || string(trimmed) == "null" {
+		return &Result{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("invalid JSON: expected an array of entries")
+	}
+
+	result := &Result{}
+	for decoder.More() {
+		line := lineAt(data, decoder.InputOffset())
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON on line %d: %w", line, err)
+		}
+		p.addJSONEntry(result, line, raw)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseJSONLines reads one entry object per line
+func (p *parser) parseJSONLines(r io.Reader) (*Result, error) {
+	result := &Result{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		p.addJSONEntry(result, line, json.RawMessage(text))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSON Lines: %w", err)
+	}
+
+	return result, nil
+}
+
+// addJSONEntry validates one exported entry and adds it to the result
+func (p *parser) addJSONEntry(result *Result, line int, raw json.RawMessage) {
+	var record jsonEntry
+	if err := json.Unmarshal(raw, &record); err != nil {
+		result.reject(line, "invalid JSON: %v", err)
+		return
+	}
+
+	date, err := p.date(record.Date)
+	if err != nil {
+		result.reject(line, "%v", err)
+		return
+	}
+
+	if record.Amount == nil {
+		result.reject(line, "missing amount")
+		return
+	}
+
+	source := strings.ToLower(strings.TrimSpace(record.Source))
+	if !models.IsValidSource(source) {
+		result.reject(line, "invalid source '%s'", record.Source)
+		return
+	}
+
+	entryType := strings.ToLower(strings.TrimSpace(record.Type))
+	if !models.IsValidType(entryType) {
+		result.reject(line, "invalid type '%s'", record.Type)
+		return
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(record.Currency))
+	if currency != "" && !models.IsValidCurrency(currency) {
+		result.reject(line, "invalid currency '%s'", record.Currency)
+		return
+	}
+
+	result.Entries = append(result.Entries, Entry{
+		Line:     line,
+		Date:     date,
+		Amount:   int64(math.Round(*record.Amount * 100)),
+		Currency: currency,
+		Source:   source,
+		Type:     entryType,
+		Note:     record.Note,
+		Customer: strings.TrimSpace(record.Customer),
+	})
+}
+
+// lineAt returns the line of the first value at or after offset, skipping
+// the whitespace and comma between array elements
+func lineAt(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[i])) {
+		i++
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+// reverseEntries puts newest-first exported entries back in the order they
+// were added, so re-exporting lists entries with the same date identically
+func reverseEntries(entries []Entry) {
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+}
@@ -0,0 +1,99 @@
+package importer
+
+import "strings"
+
+// parseLemonSqueezy parses Lemon Squeezy's orders export. Pending, failed
+// and refunded orders are skipped and partial refunds netted out. Orders
+// for a subscription product are recurring; yearly ones are spread over
+// the twelve months they pay for.
+func (p *parser) parseLemonSqueezy(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"Total", "total", "Subtotal", "subtotal"}
+	dateCols := []string{"Created At", "created_at", "Date", "Order Date"}
+	if err := cols.require(amountCols, dateCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		switch status := strings.ToLower(cols.get(record, "Status", "status")); status {
+		case "", "paid", "partial_refund", "partially refunded":
+		case "refunded":
+			result.skip(line, "refunded order")
+			continue
+		default:
+			result.skip(line, "%s order", status)
+			continue
+		}
+		if isTrue(cols.get(record, "Refunded", "refunded")) {
+			result.skip(line, "refunded order")
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if refunded := cols.get(record, "Refunded Amount", "refunded_amount"); refunded != "" {
+			refundedAmount, err := p.amount(refunded)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+			amount -= refundedAmount
+		}
+		if amount <= 0 {
+			result.skip(line, "free or refunded order")
+			continue
+		}
+
+		date, err := p.date(cols.get(record, dateCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency := ""
+		if c := cols.get(record, "Currency", "currency"); c != "" {
+			currency, err = currencyCode(c)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+		}
+
+		entry := Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Currency: currency,
+			Source:   "lemonsqueezy",
+			Type:     "one-time",
+			Note:     cols.get(record, "Product Name", "product_name", "Product", "Order Number", "order_number"),
+			Customer: cols.get(record, "Customer Name", "user_name", "Customer Email", "user_email", "Email"),
+
+			ExternalID: cols.get(record, "Identifier", "identifier", "Order Number", "order_number", "id"),
+		}
+
+		billing := strings.ToLower(cols.get(record, "Billing Type", "Product Type", "Type"))
+		subscriptionID := cols.get(record, "Subscription ID", "subscription_id", "Subscription")
+		if subscriptionID == "" && !strings.Contains(billing, "subscription") {
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		entry.Type = "recurring"
+
+		months, _ := recurrenceMonths(cols.get(record, "Interval", "Billing Interval", "Variant Interval", "interval"))
+		if months <= 1 {
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		result.Entries = append(result.Entries, spreadMonthly(entry, date, months)...)
+	}
+
+	return result, nil
+}
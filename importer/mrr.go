@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indiekitai/mrr-cli/models"
+)
+
+// mrrColumns is the tool's own column order, used for files whose header
+// names none of the columns
+var mrrColumns = []string{"date", "amount", "source", "type", "note", "customer", "currency"}
+
+// parseMRR parses the tool's own CSV layout:
+//
+//	date,amount,source,type,note,customer,currency
+//
+// Columns are found by header name, so they may come in any order. Only date
+// and amount are required; source and type fall back to the parser's
+// defaults.
+func (p *parser) parseMRR(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	if !cols.has("date", "amount") {
+		// A header naming none of the columns, read it positionally
+		positional := make([]string, len(header))
+		copy(positional, mrrColumns)
+		cols = newColumns(positional)
+	}
+
+	if err := cols.require([]string{"date"}, []string{"amount"}); err != nil {
+		return nil, err
+	}
+	if !cols.has("source") && p.Source == "" {
+		return nil, fmt.Errorf("missing column \"source\" in CSV header (or set a default source)")
+	}
+	if !cols.has("type") && p.Type == "" {
+		return nil, fmt.Errorf("missing column \"type\" in CSV header (or set a default type)")
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		dateStr := cols.get(record, "date")
+		amountStr := cols.get(record, "amount")
+		if dateStr == "" || amountStr == "" {
+			result.reject(line, "insufficient fields")
+			continue
+		}
+
+		date, err := p.date(dateStr)
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		amount, err := p.amount(amountStr)
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		source := strings.ToLower(cols.get(record, "source"))
+		if source == "" {
+			source = p.Source
+		}
+		if !models.IsValidSource(source) {
+			result.reject(line, "invalid source '%s'", source)
+			continue
+		}
+
+		entryType := strings.ToLower(cols.get(record, "type"))
+		if entryType == "" {
+			entryType = p.Type
+		}
+		if !models.IsValidType(entryType) {
+			result.reject(line, "invalid type '%s'", entryType)
+			continue
+		}
+
+		entry := Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Source:   source,
+			Type:     entryType,
+			Note:     cols.get(record, "note"),
+			Customer: cols.get(record, "customer"),
+			Currency: strings.ToUpper(cols.get(record, "currency")),
+		}
+		if entry.Currency != "" && !models.IsValidCurrency(entry.Currency) {
+			result.reject(line, "invalid currency '%s'", entry.Currency)
+			continue
+		}
+
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result, nil
+}
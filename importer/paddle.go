@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Paddle Billing exports use API-style column names while Paddle Classic
+// uses title case, so columns list both.
+var (
+	paddleCurrency = []string{"currency_code", "Currency Code", "Currency"}
+	paddleCustomer = []string{"customer_name", "Customer Name", "customer_email", "Customer Email", "User Email", "Email", "customer_id", "Customer ID"}
+	paddleStatus   = []string{"status", "Status"}
+)
+
+// parsePaddleTransactions parses Paddle's transactions export. Only
+// completed one-off transactions are imported, as one-time revenue; refunds
+// and chargebacks are skipped, as are transactions belonging to a
+// subscription since paddle-subscriptions imports carry their MRR.
+func (p *parser) parsePaddleTransactions(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"details.totals.grand_total", "totals.grand_total", "Grand Total", "Total", "Sale Gross", "Gross", "Amount"}
+	dateCols := []string{"billed_at", "Billed At", "created_at", "Created At", "Event Time", "Date"}
+	if err := cols.require(amountCols, paddleCurrency, dateCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		switch status := strings.ToLower(cols.get(record, paddleStatus...)); status {
+		case "", "completed", "paid", "success":
+		default:
+			result.skip(line, "%s transaction", status)
+			continue
+		}
+		switch kind := strings.ToLower(cols.get(record, "Type", "type")); kind {
+		case "refund", "chargeback", "adjustment":
+			result.skip(line, "%s", kind)
+			continue
+		}
+
+		id := cols.get(record, "id", "Transaction ID", "Order ID")
+		origin := strings.ToLower(cols.get(record, "origin", "Origin"))
+		if cols.get(record, "subscription_id", "Subscription ID") != "" || strings.HasPrefix(origin, "subscription") {
+			result.skip(line, "subscription payment, counted in the subscription's MRR")
+			if id != "" {
+				result.supersede("paddle", id)
+			}
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if amount <= 0 {
+			result.skip(line, "nothing paid")
+			continue
+		}
+
+		date, err := p.date(cols.get(record, dateCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency, err := currencyCode(cols.get(record, paddleCurrency...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		result.Entries = append(result.Entries, Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Currency: currency,
+			Source:   "paddle",
+			Type:     "one-time",
+			Note:     cols.get(record, "product_name", "Product Name", "Product", "id", "Transaction ID", "Order ID"),
+			Customer: cols.get(record, paddleCustomer...),
+
+			ExternalID: id,
+		})
+	}
+
+	return result, nil
+}
+
+// parsePaddleSubscriptions parses Paddle's subscriptions export. Trialing
+// and paused subscriptions are skipped; cancelled ones keep their cancel
+// date.
+func (p *parser) parsePaddleSubscriptions(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"items.price.unit_price.amount", "unit_price", "Unit Price", "Recurring Price", "Price", "Amount"}
+	intervalCols := []string{"billing_cycle.interval", "Billing Interval", "Billing Cycle", "Interval"}
+	startCols := []string{"started_at", "Started At", "Signup Date", "Start Date", "created_at", "Created At"}
+	if err := cols.require(amountCols, paddleCurrency, intervalCols, startCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		status := strings.ToLower(cols.get(record, paddleStatus...))
+		switch status {
+		case "trialing", "paused":
+			result.skip(line, "%s subscription", status)
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if q := cols.get(record, "quantity", "items.quantity", "Quantity"); q != "" {
+			quantity, err := strconv.Atoi(q)
+			if err != nil {
+				result.reject(line, "invalid quantity '%s'", q)
+				continue
+			}
+			amount *= int64(quantity)
+		}
+		if amount <= 0 {
+			result.skip(line, "free subscription")
+			continue
+		}
+
+		count := 1
+		if f := cols.get(record, "billing_cycle.frequency", "Billing Frequency", "Frequency"); f != "" {
+			count, err = strconv.Atoi(f)
+			if err != nil || count < 1 {
+				result.reject(line, "invalid billing frequency '%s'", f)
+				continue
+			}
+		}
+
+		interval := strings.ToLower(cols.get(record, intervalCols...))
+		if months, ok := recurrenceMonths(interval); ok && months == 1 {
+			interval = "month"
+		} else if ok && months == 12 {
+			interval = "year"
+		}
+		interval, amount, err = normalizeInterval(interval, count, amount)
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		start, err := p.date(cols.get(record, startCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency, err := currencyCode(cols.get(record, paddleCurrency...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		sub := Subscription{
+			Line:      line,
+			Customer:  cols.get(record, paddleCustomer...),
+			Amount:    amount,
+			Currency:  currency,
+			Interval:  interval,
+			Source:    "paddle",
+			Note:      cols.get(record, "product_name", "Product Name", "Plan", "id", "Subscription ID"),
+			StartDate: start,
+
+			ExternalID: cols.get(record, "id", "Subscription ID"),
+		}
+		if sub.Customer == "" {
+			sub.Customer = cols.get(record, "id", "Subscription ID")
+		}
+
+		if cancelled := cols.get(record, "canceled_at", "Canceled At", "Cancelled At", "Cancellation Date"); cancelled != "" {
+			cancel, err := p.date(cancelled)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+			if cancel.Before(start) {
+				cancel = start
+			}
+			sub.CancelDate = &cancel
+		}
+
+		result.Subscriptions = append(result.Subscriptions, sub)
+	}
+
+	return result, nil
+}
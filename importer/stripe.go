@@ -0,0 +1,305 @@
+package importer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Stripe dashboard exports name the same field differently depending on
+// the report and its age, so each column lists the variants seen in the wild.
+var (
+	stripeID          = []string{"id"}
+	stripeAmount      = []string{"Amount"}
+	stripeRefunded    = []string{"Amount Refunded"}
+	stripeCurrency    = []string{"Currency"}
+	stripeCreated     = []string{"Created (UTC)", "Created date (UTC)", "Created"}
+	stripeStatus      = []string{"Status"}
+	stripeDescription = []string{"Description"}
+	stripeCustomer    = []string{"Customer Description", "Customer Name", "Customer Email", "Customer ID", "Customer"}
+	stripeSub         = []string{"Subscription ID", "Subscription"}
+	stripeCaptured    = []string{"Captured"}
+)
+
+// parseStripePayments parses Stripe's payments (charges) export. Failed,
+// uncaptured and fully refunded charges are skipped; partial refunds are
+// netted out. Charges for a subscription are skipped when the export names
+// it, since stripe-subscriptions imports carry their MRR; the rest are
+// one-time revenue.
+func (p *parser) parseStripePayments(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	if err := cols.require(stripeAmount, stripeCurrency, stripeCreated); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		status := strings.ToLower(cols.get(record, stripeStatus...))
+		switch status {
+		case "", "paid", "succeeded", "partially refunded", "partially_refunded":
+		case "refunded":
+			result.skip(line, "refunded charge")
+			continue
+		default:
+			result.skip(line, "%s charge", status)
+			continue
+		}
+		if strings.EqualFold(cols.get(record, stripeCaptured...), "false") {
+			result.skip(line, "uncaptured charge")
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, stripeAmount...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if refunded := cols.get(record, stripeRefunded...); refunded != "" {
+			refundedAmount, err := p.amount(refunded)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+			amount -= refundedAmount
+		}
+		if amount <= 0 {
+			result.skip(line, "refunded charge")
+			continue
+		}
+
+		date, err := p.date(cols.get(record, stripeCreated...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency, err := currencyCode(cols.get(record, stripeCurrency...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		id := cols.get(record, stripeID...)
+		if sub := cols.get(record, stripeSub...); sub != "" {
+			result.skip(line, "paid for subscription %s, counted in its MRR", sub)
+			if id != "" {
+				result.supersede("stripe", id)
+			}
+			continue
+		}
+
+		note := cols.get(record, stripeDescription...)
+		if note == "" {
+			note = id
+		}
+
+		result.Entries = append(result.Entries, Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Currency: currency,
+			Source:   "stripe",
+			Type:     "one-time",
+			Note:     note,
+			Customer: cols.get(record, stripeCustomer...),
+
+			ExternalID: id,
+		})
+	}
+
+	return result, nil
+}
+
+// parseStripeInvoices parses Stripe's invoices export. Only paid one-off
+// invoices are imported, as one-time revenue; subscription invoices are
+// skipped since stripe-subscriptions imports carry their MRR.
+func (p *parser) parseStripeInvoices(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"Amount Paid", "Total"}
+	dateCols := []string{"Date (UTC)", "Created (UTC)", "Finalized At (UTC)", "Date"}
+	if err := cols.require(amountCols, stripeCurrency, dateCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		status := strings.ToLower(cols.get(record, stripeStatus...))
+		if status == "" && strings.EqualFold(cols.get(record, "Paid"), "true") {
+			status = "paid"
+		}
+		if status != "paid" {
+			if status == "" {
+				status = "unpaid"
+			}
+			result.skip(line, "%s invoice", status)
+			continue
+		}
+
+		id := cols.get(record, stripeID...)
+		if sub := cols.get(record, stripeSub...); sub != "" {
+			result.skip(line, "paid for subscription %s, counted in its MRR", sub)
+			if id != "" {
+				result.supersede("stripe", id)
+			}
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if amount <= 0 {
+			result.skip(line, "nothing paid")
+			continue
+		}
+
+		date, err := p.date(cols.get(record, dateCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency, err := currencyCode(cols.get(record, stripeCurrency...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		note := cols.get(record, stripeDescription...)
+		if note == "" {
+			note = cols.get(record, "Number", "id")
+		}
+
+		result.Entries = append(result.Entries, Entry{
+			Line:     line,
+			Date:     date,
+			Amount:   amount,
+			Currency: currency,
+			Source:   "stripe",
+			Type:     "one-time",
+			Note:     note,
+			Customer: cols.get(record, "Customer Name", "Customer Email", "Customer"),
+
+			ExternalID: id,
+		})
+	}
+
+	return result, nil
+}
+
+// parseStripeSubscriptions parses Stripe's subscriptions export. Trialing
+// and never-paid subscriptions are skipped. Plans billed every N months or
+// weekly are normalized to a monthly amount; yearly plans stay yearly and
+// contribute amount/12 to MRR.
+func (p *parser) parseStripeSubscriptions(header []string, rows [][]string) (*Result, error) {
+	cols := newColumns(header)
+	amountCols := []string{"Amount", "Plan Amount", "Price"}
+	intervalCols := []string{"Interval", "Plan Interval"}
+	startCols := []string{"Start (UTC)", "Start Date (UTC)", "Start", "Created (UTC)"}
+	if err := cols.require(amountCols, stripeCurrency, intervalCols, startCols); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for i, record := range rows {
+		line := lineNumber(i)
+
+		status := strings.ToLower(cols.get(record, stripeStatus...))
+		switch status {
+		case "trialing":
+			result.skip(line, "subscription in trial")
+			continue
+		case "incomplete", "incomplete_expired":
+			result.skip(line, "%s subscription", status)
+			continue
+		}
+
+		amount, err := p.amount(cols.get(record, amountCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+		if q := cols.get(record, "Quantity"); q != "" {
+			quantity, err := strconv.Atoi(q)
+			if err != nil {
+				result.reject(line, "invalid quantity '%s'", q)
+				continue
+			}
+			amount *= int64(quantity)
+		}
+		if amount <= 0 {
+			result.skip(line, "free subscription")
+			continue
+		}
+
+		count := 1
+		if c := cols.get(record, "Interval Count", "Plan Interval Count"); c != "" {
+			count, err = strconv.Atoi(c)
+			if err != nil || count < 1 {
+				result.reject(line, "invalid interval count '%s'", c)
+				continue
+			}
+		}
+
+		interval, amount, err := normalizeInterval(strings.ToLower(cols.get(record, intervalCols...)), count, amount)
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		start, err := p.date(cols.get(record, startCols...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		currency, err := currencyCode(cols.get(record, stripeCurrency...))
+		if err != nil {
+			result.reject(line, "%v", err)
+			continue
+		}
+
+		sub := Subscription{
+			Line:      line,
+			Customer:  cols.get(record, stripeCustomer...),
+			Amount:    amount,
+			Currency:  currency,
+			Interval:  interval,
+			Source:    "stripe",
+			Note:      cols.get(record, "Product", "Plan", "id"),
+			StartDate: start,
+
+			ExternalID: cols.get(record, stripeID...),
+		}
+		if sub.Customer == "" {
+			sub.Customer = cols.get(record, stripeID...)
+		}
+
+		ended := cols.get(record, "Ended At (UTC)", "Ended At")
+		if ended == "" && status == "canceled" {
+			ended = cols.get(record, "Canceled At (UTC)", "Canceled At")
+		}
+		if ended != "" {
+			cancel, err := p.date(ended)
+			if err != nil {
+				result.reject(line, "%v", err)
+				continue
+			}
+			if cancel.Before(start) {
+				cancel = start
+			}
+			sub.CancelDate = &cancel
+		}
+
+		result.Subscriptions = append(result.Subscriptions, sub)
+	}
+
+	return result, nil
+}
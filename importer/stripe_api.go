@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/stripeapi"
+)
+
+// zeroDecimalCurrencies are charged in whole units, so Stripe amounts in
+// them are not cents
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true,
+	"KRW": true, "MGA": true, "PYG": true, "RWF": true, "UGX": true, "VND": true,
+	"VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// FromStripe converts objects read from the Stripe API into the entries and
+// subscriptions the matching CSV exports would produce, except that
+// subscription invoices and their refunds are skipped: the subscriptions
+// carry that revenue as MRR. Rows carry no line numbers; skip and reject
+// reasons name the Stripe object instead.
+func FromStripe(subs []stripeapi.Subscription, invoices []stripeapi.Invoice, refunds []stripeapi.Refund) *Result {
+	result := &Result{}
+
+	// Subscriptions of the invoices read alongside refunds, for refunds whose
+	// invoice isn't expanded
+	invoiceSubs := make(map[string]string)
+	for _, inv := range invoices {
+		addStripeInvoice(result, inv)
+		if sub := inv.SubscriptionID(); sub != "" {
+			invoiceSubs[inv.ID] = sub
+		}
+	}
+	for _, refund := range refunds {
+		addStripeRefund(result, refund, invoiceSubs)
+	}
+	for _, sub := range subs {
+		addStripeSubscription(result, sub)
+	}
+
+	assignKeys(result)
+
+	return result
+}
+
+// addStripeInvoice adds a paid one-off invoice as one-time revenue
+func addStripeInvoice(result *Result, inv stripeapi.Invoice) {
+	if inv.Status != "paid" {
+		result.skip(0, "%s: %s invoice", inv.ID, inv.Status)
+		return
+	}
+	if inv.AmountPaid <= 0 {
+		result.skip(0, "%s: nothing paid", inv.ID)
+		return
+	}
+	if sub := inv.SubscriptionID(); sub != "" {
+		result.skip(0, "%s: paid for subscription %s, counted in its MRR", inv.ID, sub)
+		result.supersede("stripe", inv.ID)
+		return
+	}
+
+	currency, err := currencyCode(inv.Currency)
+	if err != nil {
+		result.reject(0, "%s: %v", inv.ID, err)
+		return
+	}
+
+	paid := inv.Created
+	if inv.StatusTransitions.PaidAt != nil {
+		paid = *inv.StatusTransitions.PaidAt
+	}
+
+	note := inv.Description
+	if note == "" {
+		note = inv.Number
+	}
+	if note == "" {
+		note = inv.ID
+	}
+
+	customer := inv.CustomerName
+	if customer == "" {
+		customer = inv.CustomerEmail
+	}
+	if customer == "" {
+		customer = inv.Customer.DisplayName()
+	}
+
+	result.Entries = append(result.Entries, Entry{
+		Date:     unixDay(paid),
+		Amount:   minorUnits(inv.AmountPaid, currency),
+		Currency: currency,
+		Source:   "stripe",
+		Type:     "one-time",
+		Note:     note,
+		Customer: customer,
+
+		ExternalID: inv.ID,
+	})
+}
+
+// addStripeRefund adds a refund as negative one-time revenue on the day it
+// was made. Refunds of subscription invoices are skipped like the invoices; a
+// refunded subscription's MRR ends when it is canceled. An invoice's
+// subscription is taken from the expanded invoice or else from invoiceSubs;
+// refunds of invoices found in neither count as one-off refunds.
+func addStripeRefund(result *Result, refund stripeapi.Refund, invoiceSubs map[string]string) {
+	if refund.Status != "succeeded" {
+		result.skip(0, "%s: %s refund", refund.ID, refund.Status)
+		return
+	}
+	inv := refund.Charge.Invoice
+	sub := inv.SubscriptionID()
+	if sub == "" {
+		sub = invoiceSubs[inv.ID]
+	}
+	if sub != "" {
+		result.skip(0, "%s: refund of invoice %s for subscription %s, subscription MRR follows the subscription", refund.ID, inv.ID, sub)
+		result.supersede("stripe", refund.ID)
+		return
+	}
+
+	currency, err := currencyCode(refund.Currency)
+	if err != nil {
+		result.reject(0, "%s: %v", refund.ID, err)
+		return
+	}
+
+	note := "Refund"
+	if refund.Charge.Description != "" {
+		note += ": " + refund.Charge.Description
+	} else if refund.Charge.ID != "" {
+		note += " of " + refund.Charge.ID
+	}
+
+	result.Entries = append(result.Entries, Entry{
+		Date:     unixDay(refund.Created),
+		Amount:   -minorUnits(refund.Amount, currency),
+		Currency: currency,
+		Source:   "stripe",
+		Type:     "one-time",
+		Note:     note,
+		Customer: refund.Charge.Customer.DisplayName(),
+
+		ExternalID: refund.ID,
+	})
+}
+
+// addStripeSubscription adds a subscription like parseStripeSubscriptions
+func addStripeSubscription(result *Result, sub stripeapi.Subscription) {
+	switch sub.Status {
+	case "trialing":
+		result.skip(0, "%s: subscription in trial", sub.ID)
+		return
+	case "incomplete", "incomplete_expired":
+		result.skip(0, "%s: %s subscription", sub.ID, sub.Status)
+		return
+	}
+
+	if len(sub.Items.Data) == 0 {
+		result.skip(0, "%s: subscription without items", sub.ID)
+		return
+	}
+
+	first := sub.Items.Data[0].Price
+	if first.Recurring == nil {
+		result.reject(0, "%s: price %s is not recurring", sub.ID, first.ID)
+		return
+	}
+
+	currency, err := currencyCode(first.Currency)
+	if err != nil {
+		result.reject(0, "%s: %v", sub.ID, err)
+		return
+	}
+
+	var amount int64
+	for _, item := range sub.Items.Data {
+		p := item.Price
+		if p.Recurring == nil || p.Recurring.Interval != first.Recurring.Interval ||
+			p.Recurring.IntervalCount != first.Recurring.IntervalCount || !strings.EqualFold(p.Currency, first.Currency) {
+			result.reject(0, "%s: items with different billing intervals or currencies", sub.ID)
+			return
+		}
+		quantity := item.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		amount += minorUnits(p.UnitAmount, currency) * quantity
+	}
+	if amount <= 0 {
+		result.skip(0, "%s: free subscription", sub.ID)
+		return
+	}
+
+	count := first.Recurring.IntervalCount
+	if count < 1 {
+		count = 1
+	}
+	interval, amount, err := normalizeInterval(first.Recurring.Interval, count, amount)
+	if err != nil {
+		result.reject(0, "%s: %v", sub.ID, err)
+		return
+	}
+
+	start := sub.StartDate
+	if start == 0 {
+		start = sub.Created
+	}
+
+	note := first.Nickname
+	if note == "" {
+		note = string(first.Product)
+	}
+
+	s := Subscription{
+		Customer:  sub.Customer.DisplayName(),
+		Amount:    amount,
+		Currency:  currency,
+		Interval:  interval,
+		Source:    "stripe",
+		Note:      note,
+		StartDate: unixDay(start),
+
+		ExternalID: sub.ID,
+	}
+	if s.Customer == "" {
+		s.Customer = sub.ID
+	}
+
+	ended := sub.EndedAt
+	if ended == nil && sub.Status == "canceled" {
+		ended = sub.CanceledAt
+	}
+	if ended != nil {
+		cancel := unixDay(*ended)
+		if cancel.Before(s.StartDate) {
+			cancel = s.StartDate
+		}
+		s.CancelDate = &cancel
+	}
+
+	result.Subscriptions = append(result.Subscriptions, s)
+}
+
+// minorUnits converts a Stripe amount to cents
+func minorUnits(amount int64, currency string) int64 {
+	if zeroDecimalCurrencies[currency] {
+		return amount * 100
+	}
+	return amount
+}
+
+// unixDay returns the UTC day of a Unix timestamp
+func unixDay(ts int64) time.Time {
+	return timeDay(time.Unix(ts, 0))
+}
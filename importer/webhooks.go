@@ -0,0 +1,431 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indiekitai/mrr-cli/stripeapi"
+)
+
+// FromStripeEvent converts a Stripe webhook event into the rows it adds or
+// changes, keyed like FromStripe so events and syncs update the same rows.
+// Events that don't affect revenue come back as a single skipped row.
+func FromStripeEvent(payload []byte) (*Result, error) {
+	var event stripeapi.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid Stripe event: %w", err)
+	}
+
+	var (
+		subs     []stripeapi.Subscription
+		invoices []stripeapi.Invoice
+		refunds  []stripeapi.Refund
+	)
+	object := event.Data.Object
+
+	var err error
+	switch event.Type {
+	case "invoice.paid", "invoice.payment_succeeded":
+		var inv stripeapi.Invoice
+		err = json.Unmarshal(object, &inv)
+		invoices = append(invoices, inv)
+	case "customer.subscription.created", "customer.subscription.updated", "customer.subscription.deleted":
+		var sub stripeapi.Subscription
+		err = json.Unmarshal(object, &sub)
+		subs = append(subs, sub)
+	case "refund.created", "refund.updated", "charge.refund.updated":
+		var refund stripeapi.Refund
+		err = json.Unmarshal(object, &refund)
+		refunds = append(refunds, refund)
+	case "charge.refunded":
+		var charge stripeapi.Charge
+		err = json.Unmarshal(object, &charge)
+		for _, refund := range charge.Refunds.Data {
+			refund.Charge = charge
+			refund.Charge.Refunds.Data = nil
+			refunds = append(refunds, refund)
+		}
+		if err == nil && len(refunds) == 0 {
+			result := &Result{}
+			result.skip(0, "%s: charge.refunded event without refunds, subscribe to refund.created instead", event.ID)
+			return result, nil
+		}
+	default:
+		result := &Result{}
+		result.skip(0, "%s: %s events don't change revenue", event.ID, event.Type)
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", event.Type, err)
+	}
+
+	return FromStripe(subs, invoices, refunds), nil
+}
+
+// paddleEvent is a Paddle Billing notification
+type paddleEvent struct {
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// paddleTransaction is the data of a Paddle transaction event
+type paddleTransaction struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	Origin         string `json:"origin"`
+	CustomerID     string `json:"customer_id"`
+	SubscriptionID string `json:"subscription_id"`
+	CurrencyCode   string `json:"currency_code"`
+	BilledAt       string `json:"billed_at"`
+	CreatedAt      string `json:"created_at"`
+	Details        struct {
+		Totals struct {
+			GrandTotal string `json:"grand_total"`
+		} `json:"totals"`
+	} `json:"details"`
+	Items []struct {
+		Price paddlePrice `json:"price"`
+	} `json:"items"`
+}
+
+// paddleSubscription is the data of a Paddle subscription event
+type paddleSubscription struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	CustomerID   string `json:"customer_id"`
+	CurrencyCode string `json:"currency_code"`
+	StartedAt    string `json:"started_at"`
+	CreatedAt    string `json:"created_at"`
+	CanceledAt   string `json:"canceled_at"`
+	PausedAt     string `json:"paused_at"`
+	BillingCycle struct {
+		Interval  string `json:"interval"`
+		Frequency int    `json:"frequency"`
+	} `json:"billing_cycle"`
+	Items []struct {
+		Quantity int64       `json:"quantity"`
+		Price    paddlePrice `json:"price"`
+	} `json:"items"`
+}
+
+// paddlePrice is a price on a Paddle transaction or subscription item
+type paddlePrice struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UnitPrice   struct {
+		Amount       string `json:"amount"`
+		CurrencyCode string `json:"currency_code"`
+	} `json:"unit_price"`
+}
+
+// label is how a price is described in notes
+func (p paddlePrice) label() string {
+	for _, s := range []string{p.Name, p.Description, p.ID} {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// FromPaddleEvent converts a Paddle Billing notification into the rows it
+// adds or changes, following the rules of the transactions and
+// subscriptions exports. Transactions billing a subscription are skipped:
+// subscription events carry that revenue as MRR. Paused subscriptions end
+// on the day they were paused and start again when resumed.
+func FromPaddleEvent(payload []byte) (*Result, error) {
+	var event paddleEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid Paddle event: %w", err)
+	}
+
+	result := &Result{}
+
+	switch {
+	case event.EventType == "transaction.completed" || event.EventType == "transaction.paid":
+		var txn paddleTransaction
+		if err := json.Unmarshal(event.Data, &txn); err != nil {
+			return nil, fmt.Errorf("invalid %s event: %w", event.EventType, err)
+		}
+		addPaddleTransaction(result, txn)
+	case strings.HasPrefix(event.EventType, "subscription."):
+		var sub paddleSubscription
+		if err := json.Unmarshal(event.Data, &sub); err != nil {
+			return nil, fmt.Errorf("invalid %s event: %w", event.EventType, err)
+		}
+		addPaddleSubscription(result, sub)
+	default:
+		result.skip(0, "%s: %s events don't change revenue", event.EventID, event.EventType)
+		return result, nil
+	}
+
+	assignKeys(result)
+
+	return result, nil
+}
+
+// addPaddleTransaction adds a completed one-off transaction as one-time
+// revenue
+func addPaddleTransaction(result *Result, txn paddleTransaction) {
+	switch txn.Status {
+	case "completed", "paid":
+	default:
+		result.skip(0, "%s: %s transaction", txn.ID, txn.Status)
+		return
+	}
+	if txn.SubscriptionID != "" || strings.HasPrefix(txn.Origin, "subscription") {
+		result.skip(0, "%s: subscription payment, counted in the subscription's MRR", txn.ID)
+		result.supersede("paddle", txn.ID)
+		return
+	}
+
+	currency, err := currencyCode(txn.CurrencyCode)
+	if err != nil {
+		result.reject(0, "%s: %v", txn.ID, err)
+		return
+	}
+
+	amount, err := strconv.ParseInt(txn.Details.Totals.GrandTotal, 10, 64)
+	if err != nil {
+		result.reject(0, "%s: invalid total '%s'", txn.ID, txn.Details.Totals.GrandTotal)
+		return
+	}
+	if amount <= 0 {
+		result.skip(0, "%s: nothing paid", txn.ID)
+		return
+	}
+
+	billed := txn.BilledAt
+	if billed == "" {
+		billed = txn.CreatedAt
+	}
+	date, err := timestampDay(billed)
+	if err != nil {
+		result.reject(0, "%s: %v", txn.ID, err)
+		return
+	}
+
+	note := txn.ID
+	if len(txn.Items) > 0 && txn.Items[0].Price.label() != "" {
+		note = txn.Items[0].Price.label()
+	}
+
+	result.Entries = append(result.Entries, Entry{
+		Date:     date,
+		Amount:   minorUnits(amount, currency),
+		Currency: currency,
+		Source:   "paddle",
+		Type:     "one-time",
+		Note:     note,
+		Customer: txn.CustomerID,
+
+		ExternalID: txn.ID,
+	})
+}
+
+func addPaddleSubscription(result *Result, sub paddleSubscription) {
+	if sub.Status == "trialing" {
+		result.skip(0, "%s: subscription in trial", sub.ID)
+		return
+	}
+	if len(sub.Items) == 0 {
+		result.skip(0, "%s: subscription without items", sub.ID)
+		return
+	}
+
+	currency, err := currencyCode(sub.CurrencyCode)
+	if err != nil {
+		result.reject(0, "%s: %v", sub.ID, err)
+		return
+	}
+
+	var amount int64
+	for _, item := range sub.Items {
+		unit, err := strconv.ParseInt(item.Price.UnitPrice.Amount, 10, 64)
+		if err != nil {
+			result.reject(0, "%s: invalid unit price '%s'", sub.ID, item.Price.UnitPrice.Amount)
+			return
+		}
+		quantity := item.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		amount += minorUnits(unit, currency) * quantity
+	}
+	if amount <= 0 {
+		result.skip(0, "%s: free subscription", sub.ID)
+		return
+	}
+
+	count := sub.BillingCycle.Frequency
+	if count < 1 {
+		count = 1
+	}
+	interval, amount, err := normalizeInterval(sub.BillingCycle.Interval, count, amount)
+	if err != nil {
+		result.reject(0, "%s: %v", sub.ID, err)
+		return
+	}
+
+	started := sub.StartedAt
+	if started == "" {
+		started = sub.CreatedAt
+	}
+	start, err := timestampDay(started)
+	if err != nil {
+		result.reject(0, "%s: %v", sub.ID, err)
+		return
+	}
+
+	s := Subscription{
+		Customer:  sub.CustomerID,
+		Amount:    amount,
+		Currency:  currency,
+		Interval:  interval,
+		Source:    "paddle",
+		Note:      sub.Items[0].Price.label(),
+		StartDate: start,
+
+		ExternalID: sub.ID,
+	}
+	if s.Customer == "" {
+		s.Customer = sub.ID
+	}
+
+	ended := ""
+	switch sub.Status {
+	case "canceled":
+		ended = sub.CanceledAt
+	case "paused":
+		ended = sub.PausedAt
+	}
+	if ended != "" {
+		cancel, err := timestampDay(ended)
+		if err != nil {
+			result.reject(0, "%s: %v", sub.ID, err)
+			return
+		}
+		if cancel.Before(start) {
+			cancel = start
+		}
+		s.CancelDate = &cancel
+	}
+
+	result.Subscriptions = append(result.Subscriptions, s)
+}
+
+// FromGumroadPing converts a Gumroad Ping (a form-encoded sale, refund or
+// cancellation notification) into the entries it adds. Sales follow the
+// rules of the sales export; refunds are recorded as negative revenue on
+// the day of the ping. Test pings are skipped.
+func FromGumroadPing(form url.Values, now time.Time) (*Result, error) {
+	result := &Result{}
+	saleID := form.Get("sale_id")
+
+	if isTrue(form.Get("test")) {
+		result.skip(0, "%s: test ping", saleID)
+		return result, nil
+	}
+	switch resource := form.Get("resource_name"); resource {
+	case "", "sale", "refund":
+	default:
+		result.skip(0, "%s: %s pings don't change revenue", firstNonEmpty(saleID, form.Get("subscription_id")), resource)
+		return result, nil
+	}
+	if saleID == "" {
+		return nil, fmt.Errorf("invalid Gumroad ping: no sale_id")
+	}
+
+	currency := "USD"
+	if c := form.Get("currency"); c != "" {
+		var err error
+		if currency, err = currencyCode(c); err != nil {
+			result.reject(0, "%s: %v", saleID, err)
+			return result, nil
+		}
+	}
+
+	price, err := strconv.ParseInt(form.Get("price"), 10, 64)
+	if err != nil {
+		result.reject(0, "%s: invalid price '%s'", saleID, form.Get("price"))
+		return result, nil
+	}
+	amount := minorUnits(price, currency)
+
+	recurrence := form.Get("recurrence")
+	months, known := recurrenceMonths(recurrence)
+	recurring := known || isTrue(form.Get("is_recurring_charge")) || form.Get("subscription_id") != ""
+
+	entry := Entry{
+		Amount:   amount,
+		Currency: currency,
+		Source:   "gumroad",
+		Type:     "one-time",
+		Note:     firstNonEmpty(form.Get("product_name"), saleID),
+		Customer: firstNonEmpty(form.Get("full_name"), form.Get("email")),
+
+		ExternalID: saleID,
+	}
+	if recurring {
+		entry.Type = "recurring"
+	}
+
+	if isTrue(form.Get("refunded")) {
+		entry.Date = timeDay(now)
+		entry.Amount = -amount
+		entry.Note = "Refund: " + entry.Note
+		entry.ExternalID = saleID + ":refund"
+		result.Entries = append(result.Entries, entry)
+		assignKeys(result)
+		return result, nil
+	}
+
+	if amount <= 0 {
+		result.skip(0, "%s: free sale", saleID)
+		return result, nil
+	}
+	if entry.Date, err = timestampDay(form.Get("sale_timestamp")); err != nil {
+		result.reject(0, "%s: %v", saleID, err)
+		return result, nil
+	}
+
+	if recurring && months > 1 {
+		result.Entries = append(result.Entries, spreadMonthly(entry, entry.Date, months)...)
+	} else {
+		result.Entries = append(result.Entries, entry)
+	}
+	assignKeys(result)
+
+	return result, nil
+}
+
+// timestampDay returns the UTC day of an RFC 3339 timestamp
+func timestampDay(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp '%s'", s)
+	}
+	return timeDay(t), nil
+}
+
+// timeDay truncates t to its UTC day
+func timeDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// firstNonEmpty returns the first of values that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
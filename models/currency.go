@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// BaseCurrency is the currency FX rates are quoted against
+const BaseCurrency = "USD"
+
+// FXRate is the value of one unit of Currency in BaseCurrency during Month
+type FXRate struct {
+	Currency string
+	Month    string // YYYY-MM
+	Rate     float64
+}
+
+// IsValidCurrency checks if a currency looks like an ISO 4217 code
+func IsValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// FXTable converts amounts between currencies using monthly rates
+type FXTable struct {
+	rates map[string][]FXRate // Per currency, sorted by month
+}
+
+// NewFXTable builds a lookup table from a list of rates
+func NewFXTable(rates []FXRate) *FXTable {
+	t := &FXTable{rates: make(map[string][]FXRate)}
+	for _, r := range rates {
+		t.rates[r.Currency] = append(t.rates[r.Currency], r)
+	}
+	for c := range t.rates {
+		sort.Slice(t.rates[c], func(i, j int) bool {
+			return t.rates[c][i].Month < t.rates[c][j].Month
+		})
+	}
+	return t
+}
+
+// Rate returns the BaseCurrency value of one unit of currency for a month.
+// The latest rate on or before the month is used, falling back to the
+// earliest known rate for months before any rate was recorded.
+func (t *FXTable) Rate(currency, month string) (float64, error) {
+	if currency == BaseCurrency || currency == "" {
+		return 1, nil
+	}
+
+	rates := t.rates[currency]
+	if len(rates) == 0 {
+		return 0, fmt.Errorf("no FX rate for %s (set one with 'mrr fx set %s <rate>')", currency, currency)
+	}
+
+	rate := rates[0].Rate
+	for _, r := range rates {
+		if r.Month > month {
+			break
+		}
+		rate = r.Rate
+	}
+	return rate, nil
+}
+
+// Convert converts cents from one currency to another at a month's rates
+func (t *FXTable) Convert(cents int64, from, to, month string) (int64, error) {
+	if from == to {
+		return cents, nil
+	}
+
+	fromRate, err := t.Rate(from, month)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := t.Rate(to, month)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(math.Round(float64(cents) * fromRate / toRate)), nil
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Customer represents a paying customer
+type Customer struct {
+	ID        int64
+	Name      string
+	Email     string
+	Note      string
+	CreatedAt time.Time
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 )
 
@@ -9,15 +11,30 @@ import (
 type Entry struct {
 	ID        int64
 	Amount    int64  // Amount in cents
-	Source    string // stripe, gumroad, paddle, manual
+	Source    string // stripe, gumroad, paddle, lemonsqueezy, manual
 	Type      string // recurring, one-time
+	Currency  string // ISO 4217 code, e.g. USD
 	Note      string
 	Date      time.Time
 	CreatedAt time.Time
+
+	CustomerID   int64  // 0 when the entry isn't linked to a customer
+	CustomerName string // Populated from the customers table when linked
+
+	ImportKey string // Identifies the imported row this entry came from, empty if added by hand
+	Origin    string // How the entry was recorded: manual, import, sync or webhook
 }
 
+// Origins record how an entry or subscription got into the database
+const (
+	OriginManual  = "manual"  // mrr add, mrr sub add, the TUI
+	OriginImport  = "import"  // mrr import and restored backups
+	OriginSync    = "sync"    // mrr sync
+	OriginWebhook = "webhook" // Webhooks received by mrr serve
+)
+
 // ValidSources contains all valid source values
-var ValidSources = []string{"stripe", "gumroad", "paddle", "manual"}
+var ValidSources = []string{"stripe", "gumroad", "paddle", "lemonsqueezy", "manual"}
 
 // ValidTypes contains all valid type values
 var ValidTypes = []string{"recurring", "one-time"}
@@ -42,15 +59,78 @@ func IsValidType(t string) bool {
 	return false
 }
 
+// MaxAmount is the largest amount, in currency units, a single entry or
+// subscription may hold, leaving room to total many of them in int64 cents
+const MaxAmount = 1e9
+
+// AmountToCents converts an amount in currency units to cents, rounding to
+// the nearest cent. Amounts that aren't finite, round to zero or exceed
+// MaxAmount either way are rejected.
+func AmountToCents(amount float64) (int64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid amount: %v", amount)
+	}
+	if math.Abs(amount) > MaxAmount {
+		return 0, fmt.Errorf("amount out of range: %v (max %.0f)", amount, MaxAmount)
+	}
+	cents := int64(math.Round(amount * 100))
+	if cents == 0 {
+		return 0, fmt.Errorf("amount can't be zero")
+	}
+	return cents, nil
+}
+
 // FormatAmount formats cents as currency string
 func FormatAmount(cents int64, currency string) string {
-	dollars := float64(cents) / 100.0
+	prefix, suffix := currencyAffixes(currency)
+	return fmt.Sprintf("%s%.2f%s", prefix, float64(cents)/100.0, suffix)
+}
+
+// FormatCompactAmount formats cents in whole units, thousands or millions,
+// e.g. $950, $1.2k, $12k, $1.5M
+func FormatCompactAmount(cents int64, currency string) string {
+	prefix, suffix := currencyAffixes(currency)
+	return prefix + formatCompact(float64(cents)/100.0) + suffix
+}
+
+// FormatAmountRange formats a range of cents compactly, e.g. $5k–10k
+func FormatAmountRange(min, max int64, currency string) string {
+	prefix, suffix := currencyAffixes(currency)
+	return prefix + formatCompact(float64(min)/100.0) + "–" + formatCompact(float64(max)/100.0) + suffix
+}
+
+// currencyAffixes returns what goes before and after an amount in currency
+func currencyAffixes(currency string) (prefix, suffix string) {
 	switch currency {
+	case "USD", "":
+		return "$", ""
 	case "EUR":
-		return fmt.Sprintf("€%.2f", dollars)
+		return "€", ""
 	case "GBP":
-		return fmt.Sprintf("£%.2f", dollars)
+		return "£", ""
 	default:
-		return fmt.Sprintf("$%.2f", dollars)
+		return "", " " + currency
+	}
+}
+
+// formatCompact formats a number with a k or M suffix, keeping one decimal
+// below 10 of the unit
+func formatCompact(v float64) string {
+	abs := math.Abs(v)
+	unit, suffix := 1.0, ""
+	switch {
+	case abs >= 1e6:
+		unit, suffix = 1e6, "M"
+	case abs >= 1e3:
+		unit, suffix = 1e3, "k"
+	}
+	if suffix == "" {
+		return strconv.FormatFloat(math.Round(v), 'f', 0, 64)
+	}
+
+	scaled := v / unit
+	if math.Abs(scaled) < 10 {
+		return strconv.FormatFloat(math.Round(scaled*10)/10, 'f', -1, 64) + suffix
 	}
+	return strconv.FormatFloat(math.Round(scaled), 'f', 0, 64) + suffix
 }
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Expense represents a business cost. One-time expenses count in the month
+// of their date; recurring ones once a month (or a year, in the month of
+// their date) from their date's month through their end date's month.
+type Expense struct {
+	ID        int64
+	Amount    int64  // Amount in cents per charge
+	Currency  string // ISO 4217 code, e.g. USD
+	Category  string // hosting, software, marketing, payroll, contractors, fees, taxes, office, other
+	Interval  string // once, month, year
+	Note      string
+	Date      time.Time  // Date of the expense, or of the first charge if recurring
+	EndDate   *time.Time // Last charge of a recurring expense, nil while it continues
+	CreatedAt time.Time
+}
+
+// ValidExpenseCategories contains all valid expense categories
+var ValidExpenseCategories = []string{"hosting", "software", "marketing", "payroll", "contractors", "fees", "taxes", "office", "other"}
+
+// ValidExpenseIntervals contains all valid expense intervals
+var ValidExpenseIntervals = []string{"once", "month", "year"}
+
+// IsValidExpenseCategory checks if an expense category is valid
+func IsValidExpenseCategory(category string) bool {
+	for _, c := range ValidExpenseCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidExpenseInterval checks if an expense interval is valid
+func IsValidExpenseInterval(interval string) bool {
+	for _, i := range ValidExpenseIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRecurring reports whether the expense repeats
+func (e *Expense) IsRecurring() bool {
+	return e.Interval == "month" || e.Interval == "year"
+}
+
+// IsChargedIn reports whether the expense is charged in the month holding t
+func (e *Expense) IsChargedIn(t time.Time) bool {
+	month := t.Year()*12 + int(t.Month())
+	start := e.Date.Year()*12 + int(e.Date.Month())
+	if month < start {
+		return false
+	}
+	if e.EndDate != nil && month > e.EndDate.Year()*12+int(e.EndDate.Month()) {
+		return false
+	}
+
+	switch e.Interval {
+	case "month":
+		return true
+	case "year":
+		return t.Month() == e.Date.Month()
+	default:
+		return month == start
+	}
+}
+
+// MonthlyAmount returns the expense's recurring cost per month in cents, 0
+// for one-time expenses
+func (e *Expense) MonthlyAmount() int64 {
+	switch e.Interval {
+	case "month":
+		return e.Amount
+	case "year":
+		return e.Amount / 12
+	default:
+		return 0
+	}
+}
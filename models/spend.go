@@ -0,0 +1,10 @@
+package models
+
+// AcquisitionSpend is what was spent acquiring customers in a month:
+// marketing, ads, sales. It is entered by hand and used for CAC.
+type AcquisitionSpend struct {
+	Month    string // YYYY-MM
+	Amount   int64  // Amount in cents
+	Currency string
+	Note     string
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Subscription represents a recurring customer subscription
+type Subscription struct {
+	ID         int64
+	Customer   string // Customer name
+	CustomerID int64  // The customer named Customer, 0 if the name is empty
+	Amount     int64  // Plan amount in cents per billing interval
+	Interval   string // month, year
+	Source     string // stripe, gumroad, paddle, lemonsqueezy, manual
+	Currency   string // ISO 4217 code, e.g. USD
+	StartDate  time.Time
+	CancelDate *time.Time // nil while the subscription is active
+	Note       string
+	CreatedAt  time.Time
+	ImportKey  string // Identifies the imported row this subscription came from, empty if added by hand
+	Origin     string // How the subscription was recorded: manual, import, sync or webhook
+}
+
+// ValidIntervals contains all valid billing interval values
+var ValidIntervals = []string{"month", "year"}
+
+// IsValidInterval checks if a billing interval is valid
+func IsValidInterval(interval string) bool {
+	for _, i := range ValidIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// MonthlyAmount returns the subscription's contribution to MRR in cents,
+// rounded to the nearest cent for yearly subscriptions
+func (s *Subscription) MonthlyAmount() int64 {
+	if s.Interval == "year" {
+		return int64(math.Round(float64(s.Amount) / 12))
+	}
+	return s.Amount
+}
+
+// IsActiveAt reports whether the subscription is active on the given day
+func (s *Subscription) IsActiveAt(t time.Time) bool {
+	if s.StartDate.After(t) {
+		return false
+	}
+	return s.CancelDate == nil || s.CancelDate.After(t)
+}
+
+// BillingDates returns the dates the subscription was billed on, from its
+// start date up to and including until (or the day before cancellation).
+// Subscriptions started on a day some months don't have, like the 31st, are
+// billed on the last day of those months.
+func (s *Subscription) BillingDates(until time.Time) []time.Time {
+	months := 1
+	if s.Interval == "year" {
+		months = 12
+	}
+
+	var dates []time.Time
+	for i := 0; ; i++ {
+		d := AddMonthsClamped(s.StartDate, i*months)
+		if d.After(until) || (s.CancelDate != nil && !d.Before(*s.CancelDate)) {
+			break
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// AddMonthsClamped adds n months to t, moving to the last day of the
+// resulting month where it is shorter than t's day instead of overflowing
+// into the next month like time.AddDate: Jan 31 plus one month is Feb 28
+func AddMonthsClamped(t time.Time, n int) time.Time {
+	last := time.Date(t.Year(), t.Month()+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(t.Year(), t.Month()+time.Month(n), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
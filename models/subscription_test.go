@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBillingDatesClampToMonthEnd(t *testing.T) {
+	tests := []struct {
+		start    string
+		interval string
+		until    string
+		want     []string
+	}{
+		{"2026-01-31", "month", "2026-05-31", []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"}},
+		{"2024-01-30", "month", "2024-03-30", []string{"2024-01-30", "2024-02-29", "2024-03-30"}},
+		{"2024-02-29", "year", "2028-03-01", []string{"2024-02-29", "2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"}},
+		{"2026-01-15", "month", "2026-03-14", []string{"2026-01-15", "2026-02-15"}},
+	}
+	for _, tt := range tests {
+		start, _ := time.Parse("2006-01-02", tt.start)
+		until, _ := time.Parse("2006-01-02", tt.until)
+		sub := Subscription{Interval: tt.interval, StartDate: start}
+
+		var got []string
+		for _, d := range sub.BillingDates(until) {
+			got = append(got, d.Format("2006-01-02"))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s %s: billed on %v, want %v", tt.start, tt.interval, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s %s: billed on %v, want %v", tt.start, tt.interval, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMonthlyAmountRoundsYearly(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		interval string
+		want     int64
+	}{
+		{2900, "month", 2900},
+		{29000, "year", 2417}, // 2416.67
+		{10000, "year", 833},  // 833.33
+		{12000, "year", 1000},
+	}
+	for _, tt := range tests {
+		sub := Subscription{Amount: tt.amount, Interval: tt.interval}
+		if got := sub.MonthlyAmount(); got != tt.want {
+			t.Errorf("%d per %s: MonthlyAmount() = %d, want %d", tt.amount, tt.interval, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Token scopes: read tokens can only GET, read-write tokens can also change
+// entries
+const (
+	ScopeRead      = "read"
+	ScopeReadWrite = "read-write"
+)
+
+// ValidScopes contains all valid token scopes
+var ValidScopes = []string{ScopeRead, ScopeReadWrite}
+
+// IsValidScope checks if a token scope is valid
+func IsValidScope(scope string) bool {
+	for _, s := range ValidScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken is a bearer token for the REST API. The token itself is shown
+// once when created; only its hash is stored.
+type APIToken struct {
+	ID         int64
+	Name       string
+	Scope      string
+	Hash       string // Hex SHA-256 of the token
+	Prefix     string // Start of the token, to tell tokens apart
+	CreatedAt  time.Time
+	LastUsedAt *time.Time // nil if never used
+}
+
+// CanWrite reports whether the token may change data
+func (t *APIToken) CanWrite() bool {
+	return t.Scope == ScopeReadWrite
+}
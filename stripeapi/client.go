@@ -0,0 +1,150 @@
+// Package stripeapi is a minimal client for the parts of Stripe's REST API
+// that mrr sync reads: subscriptions, paid invoices and refunds.
+package stripeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is Stripe's production API
+const DefaultBaseURL = "https://api.stripe.com"
+
+// pageSize is the largest page Stripe's list endpoints return
+const pageSize = 100
+
+// Client calls the Stripe API with a secret or restricted key
+type Client struct {
+	APIKey     string
+	BaseURL    string // DefaultBaseURL if empty; point at stripe-mock for tests
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the API at baseURL
+func NewClient(apiKey, baseURL string) *Client {
+	return &Client{
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Error is an error response from the API
+type Error struct {
+	StatusCode int
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("stripe: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("stripe: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// ListSubscriptions returns every subscription in any status
+func (c *Client) ListSubscriptions() ([]Subscription, error) {
+	params := url.Values{}
+	params.Set("status", "all")
+	params.Add("expand[]", "data.customer")
+	return listAll(c, "/v1/subscriptions", params, func(s Subscription) string { return s.ID })
+}
+
+// ListPaidInvoices returns paid invoices created at or after since (a Unix
+// timestamp, 0 for all)
+func (c *Client) ListPaidInvoices(since int64) ([]Invoice, error) {
+	params := url.Values{}
+	params.Set("status", "paid")
+	if since > 0 {
+		params.Set("created[gte]", strconv.FormatInt(since, 10))
+	}
+	return listAll(c, "/v1/invoices", params, func(i Invoice) string { return i.ID })
+}
+
+// ListRefunds returns refunds created at or after since (a Unix timestamp,
+// 0 for all) with their charges and the invoices those paid expanded
+func (c *Client) ListRefunds(since int64) ([]Refund, error) {
+	params := url.Values{}
+	params.Add("expand[]", "data.charge")
+	params.Add("expand[]", "data.charge.invoice")
+	if since > 0 {
+		params.Set("created[gte]", strconv.FormatInt(since, 10))
+	}
+	return listAll(c, "/v1/refunds", params, func(r Refund) string { return r.ID })
+}
+
+// list is a page of a Stripe list endpoint
+type list[T any] struct {
+	Data    []T  `json:"data"`
+	HasMore bool `json:"has_more"`
+}
+
+// listAll follows a list endpoint's pages to the end
+func listAll[T any](c *Client, path string, params url.Values, id func(T) string) ([]T, error) {
+	params.Set("limit", strconv.Itoa(pageSize))
+
+	var all []T
+	for {
+		var page list[T]
+		if err := c.get(path, params, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Data...)
+
+		if !page.HasMore || len(page.Data) == 0 {
+			return all, nil
+		}
+		params.Set("starting_after", id(page.Data[len(page.Data)-1]))
+	}
+}
+
+// get fetches path with query params and decodes the JSON response into v
+func (c *Client) get(path string, params url.Values, v interface{}) error {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(base, "/")+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("stripe: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("stripe: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error Error `json:"error"`
+		}
+		json.Unmarshal(body, &errResp)
+		errResp.Error.StatusCode = resp.StatusCode
+		return &errResp.Error
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("stripe: invalid response from %s: %w", path, err)
+	}
+	return nil
+}
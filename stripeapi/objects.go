@@ -0,0 +1,184 @@
+package stripeapi
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Event is a webhook event; Data.Object is the object it is about
+type Event struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Subscription is a Stripe subscription
+type Subscription struct {
+	ID         string   `json:"id"`
+	Status     string   `json:"status"`
+	Created    int64    `json:"created"`
+	StartDate  int64    `json:"start_date"`
+	CanceledAt *int64   `json:"canceled_at"`
+	EndedAt    *int64   `json:"ended_at"`
+	Customer   Customer `json:"customer"`
+	Items      struct {
+		Data []SubscriptionItem `json:"data"`
+	} `json:"items"`
+}
+
+// SubscriptionItem is a price a subscription bills for
+type SubscriptionItem struct {
+	Price    Price `json:"price"`
+	Quantity int64 `json:"quantity"`
+}
+
+// Price is what a subscription item costs per billing interval
+type Price struct {
+	ID         string `json:"id"`
+	Nickname   string `json:"nickname"`
+	Currency   string `json:"currency"`
+	UnitAmount int64  `json:"unit_amount"`
+	Recurring  *struct {
+		Interval      string `json:"interval"`
+		IntervalCount int    `json:"interval_count"`
+	} `json:"recurring"`
+	Product ID `json:"product"`
+}
+
+// Invoice is a Stripe invoice
+type Invoice struct {
+	ID            string   `json:"id"`
+	Number        string   `json:"number"`
+	Status        string   `json:"status"`
+	Currency      string   `json:"currency"`
+	AmountPaid    int64    `json:"amount_paid"`
+	Created       int64    `json:"created"`
+	Description   string   `json:"description"`
+	Customer      Customer `json:"customer"`
+	CustomerName  string   `json:"customer_name"`
+	CustomerEmail string   `json:"customer_email"`
+	Subscription  ID       `json:"subscription"`
+	Parent        *struct {
+		SubscriptionDetails *struct {
+			Subscription ID `json:"subscription"`
+		} `json:"subscription_details"`
+	} `json:"parent"` // Where API versions from 2025 put the subscription
+	StatusTransitions struct {
+		PaidAt *int64 `json:"paid_at"`
+	} `json:"status_transitions"`
+	Lines struct {
+		Data []struct {
+			Period Period `json:"period"`
+		} `json:"data"`
+	} `json:"lines"`
+}
+
+// UnmarshalJSON accepts an invoice ID or an expanded invoice
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	type invoice Invoice
+	return unmarshalExpandable(data, &i.ID, (*invoice)(i))
+}
+
+// SubscriptionID returns the subscription an invoice bills for, empty for
+// one-off invoices and invoices given only as an ID
+func (i *Invoice) SubscriptionID() string {
+	if i.Subscription != "" {
+		return string(i.Subscription)
+	}
+	if i.Parent != nil && i.Parent.SubscriptionDetails != nil {
+		return string(i.Parent.SubscriptionDetails.Subscription)
+	}
+	return ""
+}
+
+// Period is a billing period as Unix timestamps
+type Period struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// Refund is a Stripe refund
+type Refund struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+	Created  int64  `json:"created"`
+	Reason   string `json:"reason"`
+	Charge   Charge `json:"charge"`
+}
+
+// Charge is the charge a refund returns money from
+type Charge struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Invoice     Invoice  `json:"invoice"` // Only the ID unless expanded
+	Customer    Customer `json:"customer"`
+	Refunds     struct {
+		Data []Refund `json:"data"`
+	} `json:"refunds"` // Included in charge.refunded events
+}
+
+// UnmarshalJSON accepts a charge ID or an expanded charge
+func (c *Charge) UnmarshalJSON(data []byte) error {
+	type charge Charge
+	return unmarshalExpandable(data, &c.ID, (*charge)(c))
+}
+
+// Customer is a Stripe customer, which API responses give as an ID unless
+// expanded
+type Customer struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UnmarshalJSON accepts a customer ID or an expanded customer
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	return unmarshalExpandable(data, &c.ID, (*customer)(c))
+}
+
+// DisplayName is the customer's name, email or ID, whichever is known
+func (c Customer) DisplayName() string {
+	for _, s := range []string{c.Name, c.Email, c.ID} {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// ID is a reference to another object, given as its ID or expanded
+type ID string
+
+// UnmarshalJSON accepts an ID, an expanded object or null
+func (id *ID) UnmarshalJSON(data []byte) error {
+	var object struct {
+		ID string `json:"id"`
+	}
+	if err := unmarshalExpandable(data, (*string)(id), &object); err != nil {
+		return err
+	}
+	if object.ID != "" {
+		*id = ID(object.ID)
+	}
+	return nil
+}
+
+// unmarshalExpandable decodes an expandable field: a JSON string is the ID,
+// an object decodes into expanded and null leaves everything empty
+func unmarshalExpandable(data []byte, id *string, expanded interface{}) error {
+	trimmed := strings.TrimSpace(string(data))
+	switch {
+	case trimmed == "null":
+		return nil
+	case strings.HasPrefix(trimmed, `"`):
+		return json.Unmarshal(data, id)
+	}
+
+	return json.Unmarshal(data, expanded)
+}
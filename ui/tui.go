@@ -14,6 +14,7 @@ import (
 
 // TUI represents the terminal UI
 type TUI struct {
+	store     db.Store
 	screen    tcell.Screen
 	entries   []models.Entry
 	selected  int
@@ -26,8 +27,8 @@ type TUI struct {
 	msgStyle  tcell.Style
 }
 
-// Run starts the TUI
-func Run() error {
+// Run starts the TUI on the given store
+func Run(store db.Store) error {
 	screen, err := tcell.NewScreen()
 	if err != nil {
 		return fmt.Errorf("failed to create screen: %w", err)
@@ -38,6 +39,7 @@ func Run() error {
 	}
 
 	tui := &TUI{
+		store:    store,
 		screen:   screen,
 		msgStyle: tcell.StyleDefault,
 	}
@@ -141,7 +143,7 @@ func (t *TUI) submitInput() bool {
 			return true
 		}
 		amountCents := int64(amountFloat * 100)
-		_, err = db.AddEntry(amountCents, "manual", "recurring", "", time.Now())
+		_, err = t.store.AddEntry(amountCents, t.store.ReportingCurrency(), "manual", "recurring", "", time.Now(), 0)
 		if err != nil {
 			t.setMessage("Error: "+err.Error(), tcell.StyleDefault.Foreground(tcell.ColorRed))
 			return true
@@ -160,7 +162,7 @@ func (t *TUI) submitInput() bool {
 		}
 		amountCents := int64(amountFloat * 100)
 		entry := t.entries[t.selected]
-		err = db.UpdateEntry(entry.ID, &amountCents, nil, nil)
+		err = t.store.UpdateEntry(entry.ID, &amountCents, nil, nil, nil, nil)
 		if err != nil {
 			t.setMessage("Error: "+err.Error(), tcell.StyleDefault.Foreground(tcell.ColorRed))
 			return true
@@ -170,7 +172,7 @@ func (t *TUI) submitInput() bool {
 	case "delete":
 		if strings.ToLower(t.inputBuf) == "y" && len(t.entries) > 0 {
 			entry := t.entries[t.selected]
-			err := db.DeleteEntry(entry.ID)
+			err := t.store.DeleteEntry(entry.ID)
 			if err != nil {
 				t.setMessage("Error: "+err.Error(), tcell.StyleDefault.Foreground(tcell.ColorRed))
 				return true
@@ -187,7 +189,7 @@ func (t *TUI) submitInput() bool {
 }
 
 func (t *TUI) refresh() {
-	entries, err := db.GetAllEntries()
+	entries, err := t.store.ListEntries("", "", "", 0)
 	if err != nil {
 		t.setMessage("Error loading entries: "+err.Error(), tcell.StyleDefault.Foreground(tcell.ColorRed))
 		return
@@ -246,14 +248,9 @@ func (t *TUI) render() {
 	title := "💰 MRR Tracker"
 	t.drawString(2, 0, title, headerStyle)
 
-	// Calculate total MRR
-	var totalMRR int64
-	for _, e := range t.entries {
-		if e.Type == "recurring" {
-			totalMRR += e.Amount
-		}
-	}
-	mrrStr := fmt.Sprintf("MRR: %s", models.FormatAmount(totalMRR, "USD"))
+	// Current MRR in the reporting currency
+	totalMRR, _ := db.GetMonthMRR(t.store, time.Now().Format("2006-01"))
+	mrrStr := fmt.Sprintf("MRR: %s", models.FormatAmount(totalMRR, t.store.ReportingCurrency()))
 	t.drawString(t.width-len(mrrStr)-2, 0, mrrStr, amountStyle.Bold(true))
 
 	// Column headers
@@ -292,15 +289,15 @@ func (t *TUI) render() {
 
 		t.drawString(2, y, fmt.Sprintf("%d", e.ID), style)
 		t.drawString(8, y, e.Date.Format("2006-01-02"), style)
-		
+
 		if i == t.selected {
-			t.drawString(20, y, models.FormatAmount(e.Amount, "USD"), selectedStyle)
+			t.drawString(20, y, models.FormatAmount(e.Amount, e.Currency), selectedStyle)
 			t.drawString(32, y, e.Source, selectedStyle)
 		} else {
-			t.drawString(20, y, models.FormatAmount(e.Amount, "USD"), amountStyle)
+			t.drawString(20, y, models.FormatAmount(e.Amount, e.Currency), amountStyle)
 			t.drawString(32, y, e.Source, sourceStyle)
 		}
-		
+
 		typeStyle := style
 		if e.Type == "one-time" && i != t.selected {
 			typeStyle = tcell.StyleDefault.Foreground(tcell.ColorYellow)
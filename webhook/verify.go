@@ -0,0 +1,91 @@
+// Package webhook verifies that webhook requests were sent by the payment
+// provider they claim to come from.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tolerance is how old a signed timestamp may be before the request is
+// treated as a replay
+const Tolerance = 5 * time.Minute
+
+// ErrInvalidSignature is returned for requests that are unsigned, signed with
+// another secret or too old
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// VerifyStripe checks a Stripe-Signature header ("t=<unix>,v1=<hex>,...")
+// against the endpoint's signing secret (whsec_...). Stripe signs
+// "<t>.<payload>" with HMAC-SHA256 and sends several v1 signatures while a
+// secret is being rolled.
+func VerifyStripe(payload []byte, header, secret string, now time.Time) error {
+	return verify(payload, header, secret, ",", "t", "v1", ".", now)
+}
+
+// VerifyPaddle checks a Paddle-Signature header ("ts=<unix>;h1=<hex>")
+// against the notification destination's secret key. Paddle signs
+// "<ts>:<payload>" with HMAC-SHA256.
+func VerifyPaddle(payload []byte, header, secret string, now time.Time) error {
+	return verify(payload, header, secret, ";", "ts", "h1", ":", now)
+}
+
+// VerifyToken compares a shared token passed with the request, for
+// providers like Gumroad that don't sign their webhooks
+func VerifyToken(token, secret string) error {
+	if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verify checks a header of key=value pairs separated by sep, holding a
+// timestamp under tsKey and hex HMAC-SHA256 signatures of
+// "<timestamp><join><payload>" under sigKey
+func verify(payload []byte, header, secret, sep, tsKey, sigKey, join string, now time.Time) error {
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, sep) {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case tsKey:
+			timestamp = value
+		case sigKey:
+			signatures = append(signatures, value)
+		}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > Tolerance || age < -Tolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + join))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		decoded, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}